@@ -0,0 +1,119 @@
+// Package analyzer defines the pluggable static-analysis engine at the core
+// of yavs. Each vulnerability class is implemented as an Analyzer and
+// registered with the package at init time, the same pattern database/sql
+// drivers and image decoders use in the standard library.
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+)
+
+// Severity classifies how serious a Finding is.
+type Severity int
+
+const (
+	Low Severity = iota
+	Medium
+	High
+	Critical
+)
+
+func (s Severity) String() string {
+	switch s {
+	case Low:
+		return "LOW"
+	case Medium:
+		return "MEDIUM"
+	case High:
+		return "HIGH"
+	case Critical:
+		return "CRITICAL"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// Finding is a single issue reported by an Analyzer.
+type Finding struct {
+	RuleID   string
+	Severity Severity
+	Message  string
+	Pos      token.Position
+	EndPos   token.Position
+}
+
+// Metadata describes an Analyzer for use by report formatters such as the
+// SARIF writer, which needs a stable id, human-readable descriptions, and a
+// help link for each rule independent of any single Finding.
+type Metadata struct {
+	ID          string
+	Title       string
+	Description string
+	HelpURI     string
+	Severity    Severity
+}
+
+// Analyzer inspects a parsed Go file and reports the findings it detects.
+type Analyzer interface {
+	Metadata() Metadata
+	Check(file *ast.File, fset *token.FileSet) []Finding
+}
+
+var registry []Analyzer
+
+// Register adds an Analyzer to the default registry. Checkers call this from
+// an init function of the package (or file) that implements them.
+func Register(a Analyzer) {
+	registry = append(registry, a)
+}
+
+// Analyzers returns every Analyzer registered so far.
+func Analyzers() []Analyzer {
+	out := make([]Analyzer, len(registry))
+	copy(out, registry)
+	return out
+}
+
+// Run executes every registered Analyzer against file and returns the
+// combined findings.
+func Run(file *ast.File, fset *token.FileSet) []Finding {
+	var findings []Finding
+	for _, a := range Analyzers() {
+		findings = append(findings, a.Check(file, fset)...)
+	}
+	return findings
+}
+
+// selectorCall splits a call of the form pkg.Func(...) into the identifier
+// used for pkg and the called function name. It reports false for calls that
+// are not a plain package-qualified selector, e.g. method calls on a value.
+func selectorCall(call *ast.CallExpr) (pkgAlias, funcName string, ok bool) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return "", "", false
+	}
+	id, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return "", "", false
+	}
+	return id.Name, sel.Sel.Name, true
+}
+
+// matchesImport reports whether alias refers to an import of wantPath in
+// file, honoring both default and renamed import aliases.
+func matchesImport(file *ast.File, alias, wantPath string) bool {
+	for _, imp := range file.Imports {
+		path := strings.Trim(imp.Path.Value, `"`)
+		if path != wantPath {
+			continue
+		}
+		if imp.Name != nil {
+			return imp.Name.Name == alias
+		}
+		base := path[strings.LastIndex(path, "/")+1:]
+		return base == alias
+	}
+	return false
+}
@@ -0,0 +1,83 @@
+package analyzer
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+)
+
+// TestSQLInjectionCheckFindsSprintfQuery is a minimal shape test for a
+// registered Analyzer: sqlInjection must flag a fmt.Sprintf-built query
+// passed to db.Query, the pattern demonstrated by getUser in
+// tests/fixtures/go_project.
+func TestSQLInjectionCheckFindsSprintfQuery(t *testing.T) {
+	src := `package fixture
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+func getUser(db *sql.DB, username string) {
+	query := fmt.Sprintf("SELECT * FROM users WHERE username = '%s'", username)
+	db.Query(query)
+}
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "fixture.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	findings := sqlInjection{}.Check(file, fset)
+	if len(findings) != 1 {
+		t.Fatalf("got %d findings, want 1: %+v", len(findings), findings)
+	}
+
+	f := findings[0]
+	if f.RuleID != sqlInjectionMeta.ID {
+		t.Errorf("RuleID = %q, want %q", f.RuleID, sqlInjectionMeta.ID)
+	}
+	if f.Severity != Critical {
+		t.Errorf("Severity = %v, want %v", f.Severity, Critical)
+	}
+	if !strings.Contains(f.Message, "Query") {
+		t.Errorf("Message = %q, want it to name the sink method", f.Message)
+	}
+}
+
+// TestSQLInjectionCheckIgnoresParameterizedQuery guards against a
+// regression where a query built without fmt.Sprintf is wrongly flagged.
+func TestSQLInjectionCheckIgnoresParameterizedQuery(t *testing.T) {
+	src := `package fixture
+
+import "database/sql"
+
+func getUser(db *sql.DB, username string) {
+	db.Query("SELECT * FROM users WHERE username = ?", username)
+}
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "fixture.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	findings := sqlInjection{}.Check(file, fset)
+	if len(findings) != 0 {
+		t.Errorf("got %d findings for a parameterized query, want 0: %+v", len(findings), findings)
+	}
+}
+
+// TestAnalyzersIncludesRegisteredCheckers is a regression test for the
+// registry itself: every checker's init function must have run and
+// registered it by the time Analyzers is called.
+func TestAnalyzersIncludesRegisteredCheckers(t *testing.T) {
+	for _, a := range Analyzers() {
+		if a.Metadata().ID == sqlInjectionMeta.ID {
+			return
+		}
+	}
+	t.Errorf("Analyzers() did not include %s", sqlInjectionMeta.ID)
+}
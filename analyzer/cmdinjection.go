@@ -0,0 +1,68 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+// commandInjection flags exec.Command/CommandContext, syscall.Exec, and
+// os.StartProcess calls that receive a non-literal argument, the pattern
+// demonstrated by executeCommand in the original yavs fixture.
+type commandInjection struct{}
+
+func init() {
+	Register(commandInjection{})
+}
+
+var commandInjectionMeta = Metadata{
+	ID:          "YAVS-CMDI-001",
+	Title:       "OS command injection",
+	Description: "A non-literal argument is passed to exec.Command, exec.CommandContext, syscall.Exec, or os.StartProcess, letting attacker-controlled input run arbitrary commands.",
+	HelpURI:     "https://github.com/josephvolmer/yavs/wiki/YAVS-CMDI-001",
+	Severity:    Critical,
+}
+
+func (commandInjection) Metadata() Metadata { return commandInjectionMeta }
+
+func (commandInjection) Check(file *ast.File, fset *token.FileSet) []Finding {
+	var findings []Finding
+	ast.Inspect(file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		alias, fn, ok := selectorCall(call)
+		if !ok {
+			return true
+		}
+
+		args := call.Args
+		switch {
+		case matchesImport(file, alias, "os/exec") && fn == "Command":
+		case matchesImport(file, alias, "os/exec") && fn == "CommandContext":
+			if len(args) > 0 {
+				args = args[1:] // skip the leading context.Context
+			}
+		case matchesImport(file, alias, "syscall") && fn == "Exec":
+		case matchesImport(file, alias, "os") && fn == "StartProcess":
+		default:
+			return true
+		}
+
+		for _, arg := range args {
+			if _, ok := arg.(*ast.BasicLit); ok {
+				continue
+			}
+			findings = append(findings, Finding{
+				RuleID:   commandInjectionMeta.ID,
+				Severity: commandInjectionMeta.Severity,
+				Message:  "non-literal argument passed to " + alias + "." + fn + " can lead to command injection",
+				Pos:      fset.Position(call.Pos()),
+				EndPos:   fset.Position(call.End()),
+			})
+			break
+		}
+		return true
+	})
+	return findings
+}
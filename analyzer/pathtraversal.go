@@ -0,0 +1,63 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+// pathTraversal flags ioutil.ReadFile/os.Open/os.OpenFile calls whose path
+// argument is not a literal, the pattern demonstrated by readFile in the
+// original yavs fixture.
+type pathTraversal struct{}
+
+func init() {
+	Register(pathTraversal{})
+}
+
+var pathTraversalMeta = Metadata{
+	ID:          "YAVS-PATH-001",
+	Title:       "Path traversal",
+	Description: "ioutil.ReadFile, os.Open, or os.OpenFile is called with a non-literal path, allowing attacker-controlled input to escape the intended directory.",
+	HelpURI:     "https://github.com/josephvolmer/yavs/wiki/YAVS-PATH-001",
+	Severity:    High,
+}
+
+func (pathTraversal) Metadata() Metadata { return pathTraversalMeta }
+
+func (pathTraversal) Check(file *ast.File, fset *token.FileSet) []Finding {
+	var findings []Finding
+	ast.Inspect(file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		alias, fn, ok := selectorCall(call)
+		if !ok {
+			return true
+		}
+
+		switch {
+		case matchesImport(file, alias, "io/ioutil") && fn == "ReadFile":
+		case matchesImport(file, alias, "os") && (fn == "Open" || fn == "OpenFile"):
+		default:
+			return true
+		}
+
+		if len(call.Args) == 0 {
+			return true
+		}
+		if _, ok := call.Args[0].(*ast.BasicLit); ok {
+			return true
+		}
+
+		findings = append(findings, Finding{
+			RuleID:   pathTraversalMeta.ID,
+			Severity: pathTraversalMeta.Severity,
+			Message:  alias + "." + fn + " called with a non-literal path; validate or filepath.Clean request-sourced paths to avoid traversal",
+			Pos:      fset.Position(call.Pos()),
+			EndPos:   fset.Position(call.End()),
+		})
+		return true
+	})
+	return findings
+}
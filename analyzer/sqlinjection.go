@@ -0,0 +1,105 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+// sqlInjection flags fmt.Sprintf-built query strings passed to database/sql
+// Query/Exec calls, the pattern demonstrated by getUser in the original yavs
+// fixture.
+type sqlInjection struct{}
+
+func init() {
+	Register(sqlInjection{})
+}
+
+var sqlInjectionMeta = Metadata{
+	ID:          "YAVS-SQLI-001",
+	Title:       "SQL injection via fmt.Sprintf",
+	Description: "A SQL query is built with fmt.Sprintf and passed to a database/sql Query or Exec method, letting attacker-controlled input alter the query. Use parameterized placeholders instead.",
+	HelpURI:     "https://github.com/josephvolmer/yavs/wiki/YAVS-SQLI-001",
+	Severity:    Critical,
+}
+
+func (sqlInjection) Metadata() Metadata { return sqlInjectionMeta }
+
+var sqlSinkMethods = map[string]bool{
+	"Query":           true,
+	"QueryContext":    true,
+	"QueryRow":        true,
+	"QueryRowContext": true,
+	"Exec":            true,
+	"ExecContext":     true,
+}
+
+func (sqlInjection) Check(file *ast.File, fset *token.FileSet) []Finding {
+	var findings []Finding
+	tainted := sprintfAssignedVars(file)
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || !sqlSinkMethods[sel.Sel.Name] {
+			return true
+		}
+		for _, arg := range call.Args {
+			if isSprintfCall(arg) || isTaintedIdent(arg, tainted) {
+				findings = append(findings, Finding{
+					RuleID:   sqlInjectionMeta.ID,
+					Severity: sqlInjectionMeta.Severity,
+					Message:  "SQL query built with fmt.Sprintf is passed to " + sel.Sel.Name + "; use parameterized placeholders instead",
+					Pos:      fset.Position(call.Pos()),
+					EndPos:   fset.Position(call.End()),
+				})
+				break
+			}
+		}
+		return true
+	})
+	return findings
+}
+
+// isSprintfCall reports whether expr is a direct call to fmt.Sprintf.
+func isSprintfCall(expr ast.Expr) bool {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	alias, fn, ok := selectorCall(call)
+	return ok && fn == "Sprintf" && alias == "fmt"
+}
+
+// isTaintedIdent reports whether expr is an identifier known to have been
+// assigned the direct result of fmt.Sprintf earlier in the same file.
+func isTaintedIdent(expr ast.Expr, tainted map[string]bool) bool {
+	id, ok := expr.(*ast.Ident)
+	return ok && tainted[id.Name]
+}
+
+// sprintfAssignedVars performs a single-pass, same-file taint sweep: any
+// variable assigned the direct result of fmt.Sprintf is considered tainted.
+// This is deliberately simple; cross-function propagation is left to the
+// SSA-based taint package.
+func sprintfAssignedVars(file *ast.File) map[string]bool {
+	tainted := map[string]bool{}
+	ast.Inspect(file, func(n ast.Node) bool {
+		assign, ok := n.(*ast.AssignStmt)
+		if !ok || len(assign.Lhs) != len(assign.Rhs) {
+			return true
+		}
+		for i, rhs := range assign.Rhs {
+			if !isSprintfCall(rhs) {
+				continue
+			}
+			if id, ok := assign.Lhs[i].(*ast.Ident); ok {
+				tainted[id.Name] = true
+			}
+		}
+		return true
+	})
+	return tainted
+}
@@ -0,0 +1,65 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+// insecureTLS flags InsecureSkipVerify being set to true, whether in a
+// composite literal (tls.Config{InsecureSkipVerify: true}) or via a plain
+// assignment, the pattern demonstrated by makeInsecureRequest in the
+// original yavs fixture.
+type insecureTLS struct{}
+
+func init() {
+	Register(insecureTLS{})
+}
+
+var insecureTLSMeta = Metadata{
+	ID:          "YAVS-TLS-001",
+	Title:       "TLS certificate verification disabled",
+	Description: "InsecureSkipVerify is set to true on a tls.Config, disabling TLS certificate verification and exposing the connection to man-in-the-middle attacks.",
+	HelpURI:     "https://github.com/josephvolmer/yavs/wiki/YAVS-TLS-001",
+	Severity:    High,
+}
+
+func (insecureTLS) Metadata() Metadata { return insecureTLSMeta }
+
+func (insecureTLS) Check(file *ast.File, fset *token.FileSet) []Finding {
+	var findings []Finding
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch v := n.(type) {
+		case *ast.KeyValueExpr:
+			key, ok := v.Key.(*ast.Ident)
+			if !ok || key.Name != "InsecureSkipVerify" || !isTrue(v.Value) {
+				return true
+			}
+			findings = append(findings, newInsecureTLSFinding(fset, v.Pos(), v.End()))
+		case *ast.AssignStmt:
+			for i, lhs := range v.Lhs {
+				sel, ok := lhs.(*ast.SelectorExpr)
+				if !ok || sel.Sel.Name != "InsecureSkipVerify" || i >= len(v.Rhs) || !isTrue(v.Rhs[i]) {
+					continue
+				}
+				findings = append(findings, newInsecureTLSFinding(fset, v.Pos(), v.End()))
+			}
+		}
+		return true
+	})
+	return findings
+}
+
+func isTrue(expr ast.Expr) bool {
+	id, ok := expr.(*ast.Ident)
+	return ok && id.Name == "true"
+}
+
+func newInsecureTLSFinding(fset *token.FileSet, pos, end token.Pos) Finding {
+	return Finding{
+		RuleID:   insecureTLSMeta.ID,
+		Severity: insecureTLSMeta.Severity,
+		Message:  "InsecureSkipVerify is set to true, disabling TLS certificate verification",
+		Pos:      fset.Position(pos),
+		EndPos:   fset.Position(end),
+	}
+}
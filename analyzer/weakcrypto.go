@@ -0,0 +1,59 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+// weakCrypto flags uses of crypto/md5 or crypto/sha1, the pattern
+// demonstrated by weakHash in the original yavs fixture. Both are
+// cryptographically broken and unsuitable for hashing passwords.
+type weakCrypto struct{}
+
+func init() {
+	Register(weakCrypto{})
+}
+
+var weakCryptoMeta = Metadata{
+	ID:          "YAVS-CRYPTO-001",
+	Title:       "Weak hash algorithm",
+	Description: "crypto/md5 or crypto/sha1 is used to hash a value. Both are cryptographically broken and unsuitable for hashing passwords.",
+	HelpURI:     "https://github.com/josephvolmer/yavs/wiki/YAVS-CRYPTO-001",
+	Severity:    High,
+}
+
+func (weakCrypto) Metadata() Metadata { return weakCryptoMeta }
+
+func (weakCrypto) Check(file *ast.File, fset *token.FileSet) []Finding {
+	var findings []Finding
+	ast.Inspect(file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		alias, fn, ok := selectorCall(call)
+		if !ok || fn != "New" {
+			return true
+		}
+
+		var pkg string
+		switch {
+		case matchesImport(file, alias, "crypto/md5"):
+			pkg = "crypto/md5"
+		case matchesImport(file, alias, "crypto/sha1"):
+			pkg = "crypto/sha1"
+		default:
+			return true
+		}
+
+		findings = append(findings, Finding{
+			RuleID:   weakCryptoMeta.ID,
+			Severity: weakCryptoMeta.Severity,
+			Message:  pkg + ".New is not suitable for hashing passwords; use golang.org/x/crypto/bcrypt instead",
+			Pos:      fset.Position(call.Pos()),
+			EndPos:   fset.Position(call.End()),
+		})
+		return true
+	})
+	return findings
+}
@@ -0,0 +1,68 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+)
+
+// insecureRandom flags math/rand calls made inside functions whose name
+// suggests they generate a token, session id, or other secret, the pattern
+// demonstrated by generateToken in the original yavs fixture.
+type insecureRandom struct{}
+
+func init() {
+	Register(insecureRandom{})
+}
+
+var insecureRandomMeta = Metadata{
+	ID:          "YAVS-RAND-001",
+	Title:       "Insecure randomness for security-sensitive value",
+	Description: "math/rand is used inside a function whose name suggests it produces a token, session id, or other secret. math/rand is not cryptographically secure; use crypto/rand instead.",
+	HelpURI:     "https://github.com/josephvolmer/yavs/wiki/YAVS-RAND-001",
+	Severity:    Medium,
+}
+
+func (insecureRandom) Metadata() Metadata { return insecureRandomMeta }
+
+var sensitiveNameParts = []string{"token", "secret", "password", "session", "csrf", "nonce"}
+
+func (insecureRandom) Check(file *ast.File, fset *token.FileSet) []Finding {
+	var findings []Finding
+	ast.Inspect(file, func(n ast.Node) bool {
+		fn, ok := n.(*ast.FuncDecl)
+		if !ok || fn.Body == nil || !nameSuggestsSecret(fn.Name.Name) {
+			return true
+		}
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			alias, _, ok := selectorCall(call)
+			if !ok || !matchesImport(file, alias, "math/rand") {
+				return true
+			}
+			findings = append(findings, Finding{
+				RuleID:   insecureRandomMeta.ID,
+				Severity: insecureRandomMeta.Severity,
+				Message:  "math/rand used to generate a value in " + fn.Name.Name + "; use crypto/rand for tokens and secrets",
+				Pos:      fset.Position(call.Pos()),
+				EndPos:   fset.Position(call.End()),
+			})
+			return true
+		})
+		return true
+	})
+	return findings
+}
+
+func nameSuggestsSecret(name string) bool {
+	lower := strings.ToLower(name)
+	for _, part := range sensitiveNameParts {
+		if strings.Contains(lower, part) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+// reflectedXSS flags fmt.Fprintf calls that write a non-literal value to an
+// http.ResponseWriter, the pattern demonstrated by search in the original
+// yavs fixture.
+type reflectedXSS struct{}
+
+func init() {
+	Register(reflectedXSS{})
+}
+
+var reflectedXSSMeta = Metadata{
+	ID:          "YAVS-XSS-001",
+	Title:       "Reflected cross-site scripting",
+	Description: "fmt.Fprintf writes a non-literal value to an http.ResponseWriter without escaping it, letting attacker-controlled input execute as script in the response.",
+	HelpURI:     "https://github.com/josephvolmer/yavs/wiki/YAVS-XSS-001",
+	Severity:    High,
+}
+
+func (reflectedXSS) Metadata() Metadata { return reflectedXSSMeta }
+
+func (reflectedXSS) Check(file *ast.File, fset *token.FileSet) []Finding {
+	var findings []Finding
+	ast.Inspect(file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		alias, fn, ok := selectorCall(call)
+		if !ok || fn != "Fprintf" || !matchesImport(file, alias, "fmt") {
+			return true
+		}
+		if len(call.Args) < 2 || !looksLikeResponseWriter(call.Args[0]) {
+			return true
+		}
+
+		for _, arg := range call.Args[2:] {
+			if _, ok := arg.(*ast.BasicLit); ok {
+				continue
+			}
+			findings = append(findings, Finding{
+				RuleID:   reflectedXSSMeta.ID,
+				Severity: reflectedXSSMeta.Severity,
+				Message:  "fmt.Fprintf writes a non-literal value to an HTTP response writer without escaping; use html/template instead",
+				Pos:      fset.Position(call.Pos()),
+				EndPos:   fset.Position(call.End()),
+			})
+			break
+		}
+		return true
+	})
+	return findings
+}
+
+// looksLikeResponseWriter relies on the conventional "w" receiver name for
+// http.ResponseWriter used throughout net/http handler code.
+func looksLikeResponseWriter(expr ast.Expr) bool {
+	id, ok := expr.(*ast.Ident)
+	return ok && id.Name == "w"
+}
@@ -0,0 +1,60 @@
+// Package autofix rewrites the vulnerable patterns the analyzer package
+// detects into safe equivalents. Each Fixer inspects and, where it
+// recognizes a rewritable shape, mutates a parsed file's AST in place using
+// go/ast and golang.org/x/tools/go/ast/astutil; callers render the result
+// with go/printer. Fixers are deliberately conservative: if a file doesn't
+// match the exact shape a Fixer knows how to rewrite safely, it leaves the
+// file untouched rather than guessing.
+package autofix
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+// Fixer rewrites file in place, reporting whether it changed anything.
+type Fixer interface {
+	Fix(file *ast.File, fset *token.FileSet) bool
+}
+
+var registry []Fixer
+
+// Register adds a Fixer to the default registry. Fixers call this from an
+// init function of the file that implements them.
+func Register(f Fixer) {
+	registry = append(registry, f)
+}
+
+// Fixers returns every Fixer registered so far.
+func Fixers() []Fixer {
+	out := make([]Fixer, len(registry))
+	copy(out, registry)
+	return out
+}
+
+// Apply runs every registered Fixer against file in turn, returning whether
+// any of them changed it.
+func Apply(file *ast.File, fset *token.FileSet) bool {
+	changed := false
+	for _, f := range Fixers() {
+		if f.Fix(file, fset) {
+			changed = true
+		}
+	}
+	return changed
+}
+
+// dropComments removes every comment group fully inside [start, end) from
+// file. Fixers that replace a statement list wholesale call this with that
+// list's original span first, so go/printer doesn't try to interleave
+// now-orphaned comments with the synthesized replacement statements.
+func dropComments(file *ast.File, start, end token.Pos) {
+	kept := file.Comments[:0]
+	for _, g := range file.Comments {
+		if g.Pos() >= start && g.End() <= end {
+			continue
+		}
+		kept = append(kept, g)
+	}
+	file.Comments = kept
+}
@@ -0,0 +1,162 @@
+package autofix
+
+import (
+	"bytes"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestApplyRewritesWeakToken is a regression test for randFixer: it applies
+// the registered Fixers to a function matching the weak math/rand token
+// shape and checks the rendered, gofmt'd output switches to crypto/rand
+// instead of the original pattern.
+func TestApplyRewritesWeakToken(t *testing.T) {
+	src := `package example
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+func generateToken() string {
+	return fmt.Sprintf("%x", rand.Intn(1<<32))
+}
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "example.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !Apply(file, fset) {
+		t.Fatal("Apply reported no change for a weak token generator")
+	}
+
+	var buf bytes.Buffer
+	if err := (&printer.Config{Tabwidth: 8}).Fprint(&buf, fset, file); err != nil {
+		t.Fatal(err)
+	}
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		t.Fatalf("format.Source: %v\n%s", err, buf.String())
+	}
+
+	out := string(formatted)
+	if !strings.Contains(out, "crypto/rand") {
+		t.Errorf("fixed output doesn't import crypto/rand:\n%s", out)
+	}
+	if strings.Contains(out, "math/rand") {
+		t.Errorf("fixed output still imports math/rand:\n%s", out)
+	}
+}
+
+// TestCryptoFixerAddsHelperOnceAcrossPackage is a regression test for
+// cryptoFixer: two files in the same package that each match the weak-hash
+// shape must produce only one hashPassword declaration between them,
+// otherwise the package fails to build with "hashPassword redeclared".
+func TestCryptoFixerAddsHelperOnceAcrossPackage(t *testing.T) {
+	aSrc := `package pkg
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+)
+
+func hashA(password string) string {
+	hasher := md5.New()
+	hasher.Write([]byte(password))
+	return hex.EncodeToString(hasher.Sum(nil))
+}
+`
+	bSrc := `package pkg
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+)
+
+func hashB(password string) string {
+	hasher := sha1.New()
+	hasher.Write([]byte(password))
+	return hex.EncodeToString(hasher.Sum(nil))
+}
+`
+	dir := t.TempDir()
+	fset := token.NewFileSet()
+
+	aFile, err := parser.ParseFile(fset, filepath.Join(dir, "a.go"), aSrc, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bFile, err := parser.ParseFile(fset, filepath.Join(dir, "b.go"), bSrc, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !(cryptoFixer{}).Fix(aFile, fset) {
+		t.Fatal("Fix reported no change for a.go")
+	}
+	if !(cryptoFixer{}).Fix(bFile, fset) {
+		t.Fatal("Fix reported no change for b.go")
+	}
+
+	helpers := 0
+	for _, file := range []*ast.File{aFile, bFile} {
+		for _, decl := range file.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok && fn.Name.Name == "hashPassword" {
+				helpers++
+			}
+		}
+	}
+	if helpers != 1 {
+		t.Errorf("got %d hashPassword declarations across the package, want exactly 1", helpers)
+	}
+}
+
+// TestCredentialFixerLeavesUntouchedConstAsConst is a regression test for
+// credentialFixer: a const block with one credential-shaped spec and one
+// unrelated spec must keep the unrelated spec as a const, since demoting
+// it to var would break any use requiring a constant, e.g. an array length.
+func TestCredentialFixerLeavesUntouchedConstAsConst(t *testing.T) {
+	src := `package example
+
+const (
+	APIKey     = "sk-1234567890abcdefghijklmnopqrstuvwxyz"
+	MaxRetries = 3
+)
+
+var buf [MaxRetries]int
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "example.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !(credentialFixer{}).Fix(file, fset) {
+		t.Fatal("Fix reported no change for a const block with a credential literal")
+	}
+
+	var buf bytes.Buffer
+	if err := (&printer.Config{Tabwidth: 8}).Fprint(&buf, fset, file); err != nil {
+		t.Fatal(err)
+	}
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		t.Fatalf("format.Source: %v\n%s", err, buf.String())
+	}
+
+	out := string(formatted)
+	if !strings.Contains(out, "const") || !strings.Contains(out, "MaxRetries = 3") {
+		t.Errorf("MaxRetries was demoted out of its const block:\n%s", out)
+	}
+	if !strings.Contains(out, `os.Getenv("API_KEY")`) {
+		t.Errorf("APIKey wasn't rewritten to os.Getenv:\n%s", out)
+	}
+}
@@ -0,0 +1,109 @@
+package autofix
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// randFixer rewrites the common "return fmt.Sprintf(verb, rand.Intn(n))"
+// token-generator shape into a crypto/rand-backed equivalent, fixing
+// analyzer.RuleID YAVS-RAND-001. Like cryptoFixer, it only rewrites the one
+// shape it can do so safely; anything else is left untouched.
+type randFixer struct{}
+
+func init() {
+	Register(randFixer{})
+}
+
+var sensitiveFuncNameParts = []string{"token", "secret", "password", "session", "csrf", "nonce"}
+
+func (randFixer) Fix(file *ast.File, fset *token.FileSet) bool {
+	changed := false
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || !nameSuggestsSecretFunc(fn.Name.Name) || !matchWeakRandomBody(fn) {
+			continue
+		}
+
+		dropComments(file, fn.Body.Pos(), fn.Body.End())
+		fn.Body.List = []ast.Stmt{
+			&ast.AssignStmt{
+				Lhs: []ast.Expr{ast.NewIdent("buf")},
+				Tok: token.DEFINE,
+				Rhs: []ast.Expr{&ast.CallExpr{
+					Fun: ast.NewIdent("make"),
+					Args: []ast.Expr{
+						&ast.ArrayType{Elt: ast.NewIdent("byte")},
+						&ast.BasicLit{Kind: token.INT, Value: "16"},
+					},
+				}},
+			},
+			&ast.IfStmt{
+				Init: &ast.AssignStmt{
+					Lhs: []ast.Expr{ast.NewIdent("_"), ast.NewIdent("err")},
+					Tok: token.DEFINE,
+					Rhs: []ast.Expr{&ast.CallExpr{
+						Fun:  &ast.SelectorExpr{X: ast.NewIdent("cryptorand"), Sel: ast.NewIdent("Read")},
+						Args: []ast.Expr{ast.NewIdent("buf")},
+					}},
+				},
+				Cond: &ast.BinaryExpr{X: ast.NewIdent("err"), Op: token.NEQ, Y: ast.NewIdent("nil")},
+				Body: &ast.BlockStmt{List: []ast.Stmt{
+					&ast.ExprStmt{X: &ast.CallExpr{Fun: ast.NewIdent("panic"), Args: []ast.Expr{ast.NewIdent("err")}}},
+				}},
+			},
+			&ast.ReturnStmt{Results: []ast.Expr{&ast.CallExpr{
+				Fun:  &ast.SelectorExpr{X: ast.NewIdent("hex"), Sel: ast.NewIdent("EncodeToString")},
+				Args: []ast.Expr{ast.NewIdent("buf")},
+			}}},
+		}
+		changed = true
+	}
+
+	if changed {
+		astutil.AddNamedImport(fset, file, "cryptorand", "crypto/rand")
+		astutil.AddImport(fset, file, "encoding/hex")
+		removeImportIfUnused(file, fset, "math/rand")
+		removeImportIfUnused(file, fset, "fmt")
+	}
+	return changed
+}
+
+func nameSuggestsSecretFunc(name string) bool {
+	lower := strings.ToLower(name)
+	for _, part := range sensitiveFuncNameParts {
+		if strings.Contains(lower, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchWeakRandomBody reports whether fn's body is exactly
+// "return fmt.Sprintf(verb, rand.Intn(n))".
+func matchWeakRandomBody(fn *ast.FuncDecl) bool {
+	if fn.Body == nil || len(fn.Body.List) != 1 {
+		return false
+	}
+	ret, ok := fn.Body.List[0].(*ast.ReturnStmt)
+	if !ok || len(ret.Results) != 1 {
+		return false
+	}
+	sprintf, ok := ret.Results[0].(*ast.CallExpr)
+	if !ok || !isSprintfCall(sprintf) || len(sprintf.Args) != 2 {
+		return false
+	}
+	intnCall, ok := sprintf.Args[1].(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	sel, ok := intnCall.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Intn" {
+		return false
+	}
+	pkg, ok := sel.X.(*ast.Ident)
+	return ok && pkg.Name == "rand"
+}
@@ -0,0 +1,180 @@
+package autofix
+
+import (
+	"go/ast"
+	"go/token"
+	"regexp"
+	"strconv"
+
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// sqlFixer rewrites db.Query(fmt.Sprintf("... '%s' ...", x)) into
+// db.Query("... = ? ...", x), turning analyzer.RuleID YAVS-SQLI-001 into a
+// parameterized query.
+type sqlFixer struct{}
+
+func init() {
+	Register(sqlFixer{})
+}
+
+var sqlFixSinkMethods = map[string]bool{
+	"Query":           true,
+	"QueryContext":    true,
+	"QueryRow":        true,
+	"QueryRowContext": true,
+	"Exec":            true,
+	"ExecContext":     true,
+}
+
+// sprintfVerb matches a Sprintf verb that is safe to turn into a "?"
+// placeholder, whether or not it is wrapped in SQL string-literal quotes.
+var sprintfVerb = regexp.MustCompile(`'(%[sdv])'|(%[sdv])`)
+
+func (sqlFixer) Fix(file *ast.File, fset *token.FileSet) bool {
+	changed := false
+	astutil.Apply(file, nil, func(c *astutil.Cursor) bool {
+		if call, ok := c.Node().(*ast.CallExpr); ok && fixInlineSprintfSink(call) {
+			changed = true
+		}
+		if block, ok := c.Node().(*ast.BlockStmt); ok && fixSprintfThenSink(file, block) {
+			changed = true
+		}
+		return true
+	})
+	return changed
+}
+
+// fixInlineSprintfSink rewrites db.Query(fmt.Sprintf(lit, args...)) in place.
+func fixInlineSprintfSink(call *ast.CallExpr) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || !sqlFixSinkMethods[sel.Sel.Name] {
+		return false
+	}
+
+	for i, arg := range call.Args {
+		sprintf, ok := arg.(*ast.CallExpr)
+		if !ok || !isSprintfCall(sprintf) {
+			continue
+		}
+		placeholder, extra, ok := sprintfToPlaceholder(sprintf)
+		if !ok {
+			continue
+		}
+
+		args := make([]ast.Expr, 0, len(call.Args)+len(extra)-1)
+		args = append(args, call.Args[:i]...)
+		args = append(args, placeholder)
+		args = append(args, extra...)
+		args = append(args, call.Args[i+1:]...)
+		call.Args = args
+		return true
+	}
+	return false
+}
+
+// fixSprintfThenSink rewrites the common idiom where the query is built in a
+// preceding statement ("query := fmt.Sprintf(lit, args...)") and then passed
+// by name to the sink on the very next statement ("rows, err :=
+// db.Query(query)"), folding the two into a single parameterized call.
+func fixSprintfThenSink(file *ast.File, block *ast.BlockStmt) bool {
+	changed := false
+	for i := 0; i+1 < len(block.List); i++ {
+		assign, ok := block.List[i].(*ast.AssignStmt)
+		if !ok || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+			continue
+		}
+		name, ok := assign.Lhs[0].(*ast.Ident)
+		if !ok {
+			continue
+		}
+		sprintf, ok := assign.Rhs[0].(*ast.CallExpr)
+		if !ok || !isSprintfCall(sprintf) {
+			continue
+		}
+		placeholder, extra, ok := sprintfToPlaceholder(sprintf)
+		if !ok {
+			continue
+		}
+
+		next, ok := block.List[i+1].(*ast.AssignStmt)
+		if !ok {
+			continue
+		}
+		sink, ok := next.Rhs[0].(*ast.CallExpr)
+		if !ok || len(next.Rhs) != 1 {
+			continue
+		}
+		sel, ok := sink.Fun.(*ast.SelectorExpr)
+		if !ok || !sqlFixSinkMethods[sel.Sel.Name] {
+			continue
+		}
+
+		for j, arg := range sink.Args {
+			use, ok := arg.(*ast.Ident)
+			if !ok || use.Name != name.Name {
+				continue
+			}
+			args := make([]ast.Expr, 0, len(sink.Args)+len(extra)-1)
+			args = append(args, sink.Args[:j]...)
+			args = append(args, placeholder)
+			args = append(args, extra...)
+			args = append(args, sink.Args[j+1:]...)
+			sink.Args = args
+
+			dropComments(file, assign.Pos(), assign.End())
+			block.List = append(block.List[:i], block.List[i+1:]...)
+			changed = true
+			i--
+			break
+		}
+	}
+	return changed
+}
+
+// sprintfToPlaceholder turns a fmt.Sprintf(lit, args...) call into a "?"
+// placeholder literal plus the remaining args, provided lit is a plain
+// string literal containing at least one rewritable verb.
+func sprintfToPlaceholder(sprintf *ast.CallExpr) (ast.Expr, []ast.Expr, bool) {
+	if len(sprintf.Args) == 0 {
+		return nil, nil, false
+	}
+	query, ok := sprintf.Args[0].(*ast.BasicLit)
+	if !ok || query.Kind != token.STRING {
+		return nil, nil, false
+	}
+	placeholder, ok := placeholderize(query.Value)
+	if !ok {
+		return nil, nil, false
+	}
+	return &ast.BasicLit{Kind: token.STRING, Value: placeholder}, sprintf.Args[1:], true
+}
+
+// isSprintfCall reports whether expr is a direct call to fmt.Sprintf.
+func isSprintfCall(expr ast.Expr) bool {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	pkg, ok := sel.X.(*ast.Ident)
+	return ok && pkg.Name == "fmt" && sel.Sel.Name == "Sprintf"
+}
+
+// placeholderize replaces each %s/%d/%v verb in a quoted Go string literal
+// with a "?" placeholder, unwrapping a surrounding pair of single quotes if
+// present (the common "'%s'" SQL string-literal idiom).
+func placeholderize(rawLit string) (string, bool) {
+	unquoted, err := strconv.Unquote(rawLit)
+	if err != nil {
+		return "", false
+	}
+	replaced := sprintfVerb.ReplaceAllString(unquoted, "?")
+	if replaced == unquoted {
+		return "", false
+	}
+	return strconv.Quote(replaced), true
+}
@@ -0,0 +1,46 @@
+package autofix
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+// tlsFixer flips InsecureSkipVerify from true to false, whether set via a
+// composite literal or a plain assignment, fixing analyzer.RuleID
+// YAVS-TLS-001.
+type tlsFixer struct{}
+
+func init() {
+	Register(tlsFixer{})
+}
+
+func (tlsFixer) Fix(file *ast.File, fset *token.FileSet) bool {
+	changed := false
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch v := n.(type) {
+		case *ast.KeyValueExpr:
+			key, ok := v.Key.(*ast.Ident)
+			if !ok || key.Name != "InsecureSkipVerify" || !isTrueIdent(v.Value) {
+				return true
+			}
+			v.Value = ast.NewIdent("false")
+			changed = true
+		case *ast.AssignStmt:
+			for i, lhs := range v.Lhs {
+				sel, ok := lhs.(*ast.SelectorExpr)
+				if !ok || sel.Sel.Name != "InsecureSkipVerify" || i >= len(v.Rhs) || !isTrueIdent(v.Rhs[i]) {
+					continue
+				}
+				v.Rhs[i] = ast.NewIdent("false")
+				changed = true
+			}
+		}
+		return true
+	})
+	return changed
+}
+
+func isTrueIdent(expr ast.Expr) bool {
+	id, ok := expr.(*ast.Ident)
+	return ok && id.Name == "true"
+}
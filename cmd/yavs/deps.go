@@ -0,0 +1,76 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/josephvolmer/yavs/deps"
+	"github.com/josephvolmer/yavs/report"
+)
+
+// runDeps implements the "deps" subcommand: it resolves root's dependency
+// graph and reports any modules with known OSV.dev vulnerabilities. Unlike
+// the root command's flags, these are parsed from args with their own
+// flag.FlagSet, since "deps" takes over subcommand-style rather than being
+// layered onto the default scan.
+func runDeps(args []string) {
+	fs := flag.NewFlagSet("deps", flag.ExitOnError)
+	var (
+		format          string
+		sarifOut        string
+		offline         string
+		prefetchOffline string
+	)
+	fs.StringVar(&format, "format", "text", "output format: text, json, or sarif")
+	fs.StringVar(&sarifOut, "sarif-out", "", "also write a SARIF 2.1.0 report to this path")
+	fs.StringVar(&offline, "offline", "", "check against a prefetched directory of OSV.dev vulnerability records instead of querying OSV.dev")
+	fs.StringVar(&prefetchOffline, "prefetch-offline", "", "fetch OSV.dev records for root's dependencies into this directory, for later use with -offline, then exit")
+	fs.Parse(args)
+
+	root := "."
+	if fs.NArg() > 0 {
+		root = fs.Arg(0)
+	}
+
+	if prefetchOffline != "" {
+		if err := deps.PrefetchOffline(root, prefetchOffline); err != nil {
+			fmt.Fprintln(os.Stderr, "yavs deps:", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	findings, err := deps.Scan(root, offline)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "yavs deps:", err)
+		os.Exit(1)
+	}
+
+	run := report.Run{Analyzer: deps.Analyzer{}}
+	for _, f := range findings {
+		run.Findings = append(run.Findings, f.ToAnalyzerFinding())
+	}
+	runs := []report.Run{run}
+
+	formatter, ok := report.Lookup(format)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "yavs deps: unknown format %q\n", format)
+		os.Exit(2)
+	}
+	if err := formatter.Format(os.Stdout, runs); err != nil {
+		fmt.Fprintln(os.Stderr, "yavs deps:", err)
+		os.Exit(1)
+	}
+
+	if sarifOut != "" {
+		if err := writeSARIF(sarifOut, runs); err != nil {
+			fmt.Fprintln(os.Stderr, "yavs deps:", err)
+			os.Exit(1)
+		}
+	}
+
+	if len(findings) > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,309 @@
+// Command yavs walks a Go module and reports the vulnerability classes
+// registered with the analyzer package.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+
+	"github.com/josephvolmer/yavs/analyzer"
+	"github.com/josephvolmer/yavs/autofix"
+	"github.com/josephvolmer/yavs/report"
+	"github.com/josephvolmer/yavs/secrets"
+	"github.com/josephvolmer/yavs/taint"
+)
+
+func main() {
+	if len(os.Args) > 1 && os.Args[1] == "deps" {
+		runDeps(os.Args[2:])
+		return
+	}
+
+	var (
+		format        string
+		sarifOut      string
+		enableTaint   bool
+		fixMode       bool
+		dryRun        bool
+		secretsRules  string
+		secretsAllDir bool
+	)
+	flag.StringVar(&format, "format", "text", "output format: text, json, or sarif")
+	flag.StringVar(&sarifOut, "sarif-out", "", "also write a SARIF 2.1.0 report to this path")
+	flag.BoolVar(&enableTaint, "taint", false, "also run interprocedural taint analysis (requires a loadable Go module)")
+	flag.BoolVar(&fixMode, "fix", false, "rewrite flagged patterns into safe equivalents instead of reporting")
+	flag.BoolVar(&dryRun, "dry-run", false, "with --fix, print a diff instead of writing files")
+	flag.StringVar(&secretsRules, "secrets-rules", "", "load additional secret-detection rules from this YAML file")
+	flag.BoolVar(&secretsAllDir, "secrets-all-files", false, "also scan non-Go files under root for secrets")
+	flag.Parse()
+
+	root := "."
+	if flag.NArg() > 0 {
+		root = flag.Arg(0)
+	}
+
+	if secretsRules != "" {
+		if err := secrets.LoadRulesFile(secretsRules); err != nil {
+			fmt.Fprintln(os.Stderr, "yavs:", err)
+			os.Exit(1)
+		}
+	}
+
+	if fixMode {
+		if err := fix(root, dryRun); err != nil {
+			fmt.Fprintln(os.Stderr, "yavs:", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	runs, err := scan(root)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "yavs:", err)
+		os.Exit(1)
+	}
+
+	if enableTaint {
+		taintRun, err := scanTaint(root)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "yavs: taint analysis:", err)
+			os.Exit(1)
+		}
+		runs = append(runs, taintRun)
+	}
+
+	if secretsAllDir {
+		secretsRun, err := scanSecretsFiles(root)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "yavs: secrets file scan:", err)
+			os.Exit(1)
+		}
+		runs = append(runs, secretsRun)
+	}
+
+	formatter, ok := report.Lookup(format)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "yavs: unknown format %q\n", format)
+		os.Exit(2)
+	}
+	if err := formatter.Format(os.Stdout, runs); err != nil {
+		fmt.Fprintln(os.Stderr, "yavs:", err)
+		os.Exit(1)
+	}
+
+	if sarifOut != "" {
+		if err := writeSARIF(sarifOut, runs); err != nil {
+			fmt.Fprintln(os.Stderr, "yavs:", err)
+			os.Exit(1)
+		}
+	}
+
+	if countFindings(runs) > 0 {
+		os.Exit(1)
+	}
+}
+
+// scan parses every .go file under root and runs each registered Analyzer
+// against each one, returning one report.Run per Analyzer with its findings
+// sorted by file and line.
+func scan(root string) ([]report.Run, error) {
+	analyzers := analyzer.Analyzers()
+	runs := make([]report.Run, len(analyzers))
+	for i, a := range analyzers {
+		runs[i].Analyzer = a
+	}
+
+	fset := token.NewFileSet()
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+
+		file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+		if err != nil {
+			return fmt.Errorf("parsing %s: %w", path, err)
+		}
+		for i, a := range analyzers {
+			runs[i].Findings = append(runs[i].Findings, a.Check(file, fset)...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range runs {
+		sort.Slice(r.Findings, func(i, j int) bool {
+			if r.Findings[i].Pos.Filename != r.Findings[j].Pos.Filename {
+				return r.Findings[i].Pos.Filename < r.Findings[j].Pos.Filename
+			}
+			return r.Findings[i].Pos.Line < r.Findings[j].Pos.Line
+		})
+	}
+	return runs, nil
+}
+
+// fix walks root, applying every registered autofix.Fixer to each .go file.
+// In dry-run mode it prints a unified diff per changed file instead of
+// writing it back. Credentials moved into the environment are collected
+// into a .env.example alongside root.
+func fix(root string, dryRun bool) error {
+	fset := token.NewFileSet()
+
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+
+		original, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", path, err)
+		}
+
+		file, err := parser.ParseFile(fset, path, original, parser.ParseComments)
+		if err != nil {
+			return fmt.Errorf("parsing %s: %w", path, err)
+		}
+		if !autofix.Apply(file, fset) {
+			return nil
+		}
+
+		var buf bytes.Buffer
+		cfg := printer.Config{Mode: printer.UseSpaces | printer.TabIndent, Tabwidth: 8}
+		if err := cfg.Fprint(&buf, fset, file); err != nil {
+			return fmt.Errorf("rendering %s: %w", path, err)
+		}
+
+		formatted, err := format.Source(buf.Bytes())
+		if err != nil {
+			return fmt.Errorf("formatting %s: %w", path, err)
+		}
+
+		if dryRun {
+			diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+				A:        difflib.SplitLines(string(original)),
+				B:        difflib.SplitLines(string(formatted)),
+				FromFile: path,
+				ToFile:   path + " (fixed)",
+				Context:  3,
+			})
+			if err != nil {
+				return err
+			}
+			fmt.Print(diff)
+			return nil
+		}
+
+		if err := os.WriteFile(path, formatted, info.Mode()); err != nil {
+			return fmt.Errorf("writing %s: %w", path, err)
+		}
+		fmt.Printf("fixed %s\n", path)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if !dryRun && len(autofix.EnvEntries) > 0 {
+		return writeEnvExample(root, autofix.EnvEntries)
+	}
+	return nil
+}
+
+// writeEnvExample appends each "NAME=" entry in entries to a .env.example
+// file under root, creating it if necessary and skipping entries it already
+// contains so repeated --fix runs don't duplicate lines.
+func writeEnvExample(root string, entries []string) error {
+	path := filepath.Join(root, ".env.example")
+
+	existing := map[string]bool{}
+	if contents, err := os.ReadFile(path); err == nil {
+		for _, line := range strings.Split(string(contents), "\n") {
+			existing[line] = true
+		}
+	} else if !os.IsNotExist(err) {
+		return fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("opening %s: %w", path, err)
+	}
+	defer f.Close()
+
+	for _, entry := range entries {
+		if existing[entry] {
+			continue
+		}
+		if _, err := fmt.Fprintln(f, entry); err != nil {
+			return fmt.Errorf("writing %s: %w", path, err)
+		}
+		existing[entry] = true
+	}
+	return nil
+}
+
+// scanSecretsFiles runs secrets.ScanDir over root and wraps its findings in
+// a report.Run, covering non-Go files the secrets.Analyzer registered with
+// analyzer.Registry can't see.
+func scanSecretsFiles(root string) (report.Run, error) {
+	findings, err := secrets.ScanDir(root)
+	if err != nil {
+		return report.Run{}, err
+	}
+	return report.Run{Analyzer: secrets.Analyzer{}, Findings: findings}, nil
+}
+
+// scanTaint runs the SSA-based interprocedural taint analysis over root and
+// wraps its findings in a report.Run alongside the AST-based checkers'.
+func scanTaint(root string) (report.Run, error) {
+	findings, err := taint.Analyze(root, "./...")
+	if err != nil {
+		return report.Run{}, err
+	}
+
+	run := report.Run{Analyzer: taint.Analyzer{}}
+	for _, f := range findings {
+		run.Findings = append(run.Findings, f.ToAnalyzerFinding())
+	}
+	return run, nil
+}
+
+// writeSARIF writes a SARIF 2.1.0 report for runs to path, regardless of the
+// --format the user selected for stdout.
+func writeSARIF(path string, runs []report.Run) error {
+	formatter, _ := report.Lookup("sarif")
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("creating %s: %w", path, err)
+	}
+	defer f.Close()
+
+	return formatter.Format(f, runs)
+}
+
+func countFindings(runs []report.Run) int {
+	n := 0
+	for _, r := range runs {
+		n += len(r.Findings)
+	}
+	return n
+}
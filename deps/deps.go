@@ -0,0 +1,154 @@
+// Package deps implements software composition analysis: it resolves a Go
+// module's dependency graph and reports which of those dependencies have
+// known vulnerabilities in the OSV.dev database. Unlike the AST-based
+// checkers, it needs the resolved module graph rather than a parsed file,
+// so it is driven by cmd/yavs's "deps" subcommand rather than through the
+// analyzer.Registry.
+package deps
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"golang.org/x/mod/modfile"
+
+	"github.com/josephvolmer/yavs/analyzer"
+)
+
+// RuleID identifies every finding this package produces.
+const RuleID = "YAVS-DEPS-001"
+
+// RuleMetadata describes this package's rule for report formatters such as
+// the SARIF writer.
+var RuleMetadata = analyzer.Metadata{
+	ID:          RuleID,
+	Title:       "Dependency has a known vulnerability",
+	Description: "A module in the dependency graph has a vulnerability published in the OSV.dev database that affects the resolved version in use.",
+	HelpURI:     "https://github.com/josephvolmer/yavs/wiki/YAVS-DEPS-001",
+	Severity:    analyzer.High,
+}
+
+// Analyzer adapts this package's module-graph analysis to the
+// analyzer.Analyzer interface so its findings can be reported through the
+// same pipeline as the AST-based checkers. Check always returns nil: SCA
+// needs the resolved module graph, not a single file; callers run Scan
+// separately and merge the results, the same pattern taint.Analyzer uses.
+type Analyzer struct{}
+
+// Metadata implements analyzer.Analyzer.
+func (Analyzer) Metadata() analyzer.Metadata { return RuleMetadata }
+
+// Check implements analyzer.Analyzer. It is a no-op; see the package doc.
+func (Analyzer) Check(file *ast.File, fset *token.FileSet) []analyzer.Finding { return nil }
+
+// Module is a single entry in the resolved dependency graph.
+type Module struct {
+	Path    string
+	Version string
+}
+
+// Finding is a vulnerability affecting a Module in the graph.
+type Finding struct {
+	Module       Module
+	VulnID       string
+	Aliases      []string
+	Summary      string
+	FixedVersion string
+	References   []string
+	Pos          token.Position
+}
+
+// ToAnalyzerFinding renders f for formatters that only understand
+// analyzer.Finding.
+func (f Finding) ToAnalyzerFinding() analyzer.Finding {
+	msg := fmt.Sprintf("%s: %s@%s is vulnerable (%s)", f.VulnID, f.Module.Path, f.Module.Version, f.Summary)
+	if f.FixedVersion != "" {
+		msg = fmt.Sprintf("%s — fixed in %s", msg, f.FixedVersion)
+	}
+	return analyzer.Finding{
+		RuleID:   RuleID,
+		Severity: RuleMetadata.Severity,
+		Message:  msg,
+		Pos:      f.Pos,
+		EndPos:   f.Pos,
+	}
+}
+
+// Scan resolves dir's dependency graph and checks it against OSV.dev,
+// or, if offlineDir is non-empty, against a local dump of OSV records
+// previously fetched with PrefetchOffline.
+func Scan(dir, offlineDir string) ([]Finding, error) {
+	modules, positions, err := resolveGraph(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	if offlineDir != "" {
+		vulns, err := loadOfflineDump(offlineDir)
+		if err != nil {
+			return nil, err
+		}
+		return findOffline(modules, positions, vulns), nil
+	}
+	return findOnline(modules, positions)
+}
+
+// resolveGraph parses dir's go.mod for require-statement positions and
+// resolves the full transitive module graph with `go list -m -json all`,
+// skipping the main module itself and any module locally replaced to a
+// filesystem path (which isn't a published, queryable package).
+func resolveGraph(dir string) (modules []Module, positions map[string]token.Position, err error) {
+	data, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading go.mod: %w", err)
+	}
+	mf, err := modfile.Parse("go.mod", data, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing go.mod: %w", err)
+	}
+
+	positions = map[string]token.Position{}
+	for _, req := range mf.Require {
+		positions[req.Mod.Path] = token.Position{Filename: "go.mod", Line: req.Syntax.Start.Line}
+	}
+
+	localReplace := map[string]bool{}
+	for _, rep := range mf.Replace {
+		if rep.New.Version == "" {
+			localReplace[rep.Old.Path] = true
+		}
+	}
+
+	cmd := exec.Command("go", "list", "-m", "-json", "all")
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, nil, fmt.Errorf("go list -m -json all: %w", err)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(out))
+	for dec.More() {
+		var m struct {
+			Path    string
+			Version string
+			Main    bool
+		}
+		if err := dec.Decode(&m); err != nil {
+			return nil, nil, fmt.Errorf("decoding go list output: %w", err)
+		}
+		if m.Main || m.Version == "" || localReplace[m.Path] {
+			continue
+		}
+		modules = append(modules, Module{Path: m.Path, Version: m.Version})
+		if _, ok := positions[m.Path]; !ok {
+			positions[m.Path] = token.Position{Filename: "go.mod", Line: 1}
+		}
+	}
+	return modules, positions, nil
+}
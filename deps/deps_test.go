@@ -0,0 +1,38 @@
+package deps
+
+import "testing"
+
+// TestFindOffline is a regression test for the offline matching path: a
+// module whose version falls inside a vulnerability's SEMVER range should
+// produce a Finding naming the fixed version, and one outside it shouldn't.
+func TestFindOffline(t *testing.T) {
+	vuln := Vulnerability{
+		ID:      "GO-2024-0001",
+		Summary: "example vulnerability",
+		Affected: []affected{{
+			Package: pkg{Name: "example.com/vulnerable", Ecosystem: osvEcosystem},
+			Ranges: []vrange{{
+				Type: "SEMVER",
+				Events: []event{
+					{Introduced: "0"},
+					{Fixed: "v1.2.3"},
+				},
+			}},
+		}},
+	}
+
+	modules := []Module{
+		{Path: "example.com/vulnerable", Version: "v1.0.0"},
+		{Path: "example.com/vulnerable", Version: "v1.2.3"},
+		{Path: "example.com/unrelated", Version: "v1.0.0"},
+	}
+
+	findings := findOffline(modules, nil, []Vulnerability{vuln})
+	if len(findings) != 1 {
+		t.Fatalf("got %d findings, want 1: %+v", len(findings), findings)
+	}
+	f := findings[0]
+	if f.Module.Version != "v1.0.0" || f.FixedVersion != "v1.2.3" {
+		t.Errorf("unexpected finding: %+v", f)
+	}
+}
@@ -0,0 +1,361 @@
+package deps
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"golang.org/x/mod/semver"
+)
+
+const osvEcosystem = "Go"
+
+const (
+	osvQueryBatchURL = "https://api.osv.dev/v1/querybatch"
+	osvVulnURLPrefix = "https://api.osv.dev/v1/vulns/"
+)
+
+var osvHTTPClient = &http.Client{Timeout: 30 * time.Second}
+
+// Vulnerability models the subset of the OSV schema (https://ossf.github.io/osv-schema/)
+// this package needs.
+type Vulnerability struct {
+	ID         string      `json:"id"`
+	Summary    string      `json:"summary"`
+	Details    string      `json:"details"`
+	Aliases    []string    `json:"aliases"`
+	Affected   []affected  `json:"affected"`
+	References []reference `json:"references"`
+}
+
+type affected struct {
+	Package  pkg      `json:"package"`
+	Ranges   []vrange `json:"ranges"`
+	Versions []string `json:"versions"`
+}
+
+type pkg struct {
+	Name      string `json:"name"`
+	Ecosystem string `json:"ecosystem"`
+}
+
+type vrange struct {
+	Type   string  `json:"type"`
+	Events []event `json:"events"`
+}
+
+type event struct {
+	Introduced   string `json:"introduced"`
+	Fixed        string `json:"fixed"`
+	LastAffected string `json:"last_affected"`
+}
+
+type reference struct {
+	URL string `json:"url"`
+}
+
+// findOnline queries OSV.dev for modules and returns the resulting Findings.
+func findOnline(modules []Module, positions map[string]token.Position) ([]Finding, error) {
+	ids, err := queryBatch(modules)
+	if err != nil {
+		return nil, fmt.Errorf("querying OSV.dev: %w", err)
+	}
+
+	var findings []Finding
+	for i, m := range modules {
+		for _, id := range ids[i] {
+			vuln, err := getVuln(id)
+			if err != nil {
+				return nil, fmt.Errorf("fetching %s: %w", id, err)
+			}
+			if f, ok := toFinding(m, vuln, positions[m.Path]); ok {
+				findings = append(findings, f)
+			}
+		}
+	}
+	return findings, nil
+}
+
+type querybatchRequest struct {
+	Queries []querybatchQuery `json:"queries"`
+}
+
+type querybatchQuery struct {
+	Package packageQuery `json:"package"`
+	Version string       `json:"version"`
+}
+
+type packageQuery struct {
+	Name      string `json:"name"`
+	Ecosystem string `json:"ecosystem"`
+}
+
+type querybatchResponse struct {
+	Results []struct {
+		Vulns []struct {
+			ID string `json:"id"`
+		} `json:"vulns"`
+	} `json:"results"`
+}
+
+// queryBatch asks OSV.dev which vulnerability IDs affect each module in
+// modules, in the same order. It only returns ID stubs; getVuln fetches the
+// full record for each one found.
+func queryBatch(modules []Module) ([][]string, error) {
+	req := querybatchRequest{Queries: make([]querybatchQuery, len(modules))}
+	for i, m := range modules {
+		req.Queries[i] = querybatchQuery{
+			Package: packageQuery{Name: m.Path, Ecosystem: osvEcosystem},
+			Version: m.Version,
+		}
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := osvHTTPClient.Post(osvQueryBatchURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	var parsed querybatchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, err
+	}
+
+	if len(parsed.Results) != len(modules) {
+		return nil, fmt.Errorf("OSV.dev returned %d results for %d queries", len(parsed.Results), len(modules))
+	}
+
+	ids := make([][]string, len(modules))
+	for i, r := range parsed.Results {
+		for _, v := range r.Vulns {
+			ids[i] = append(ids[i], v.ID)
+		}
+	}
+	return ids, nil
+}
+
+// getVuln fetches the full OSV record for id.
+func getVuln(id string) (Vulnerability, error) {
+	resp, err := osvHTTPClient.Get(osvVulnURLPrefix + id)
+	if err != nil {
+		return Vulnerability{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return Vulnerability{}, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	var vuln Vulnerability
+	if err := json.NewDecoder(resp.Body).Decode(&vuln); err != nil {
+		return Vulnerability{}, err
+	}
+	return vuln, nil
+}
+
+// PrefetchOffline resolves dir's dependency graph, queries OSV.dev for each
+// module's vulnerabilities, and writes one <ID>.json file per vulnerability
+// record into outDir (created if necessary) in the layout loadOfflineDump
+// expects. A later Scan(dir, outDir) then checks the graph against this
+// dump without hitting the network.
+func PrefetchOffline(dir, outDir string) error {
+	modules, _, err := resolveGraph(dir)
+	if err != nil {
+		return err
+	}
+
+	ids, err := queryBatch(modules)
+	if err != nil {
+		return fmt.Errorf("querying OSV.dev: %w", err)
+	}
+
+	if err := os.MkdirAll(outDir, 0o755); err != nil {
+		return fmt.Errorf("creating %s: %w", outDir, err)
+	}
+
+	seen := map[string]bool{}
+	for _, moduleIDs := range ids {
+		for _, id := range moduleIDs {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+
+			vuln, err := getVuln(id)
+			if err != nil {
+				return fmt.Errorf("fetching %s: %w", id, err)
+			}
+			data, err := json.MarshalIndent(vuln, "", "  ")
+			if err != nil {
+				return fmt.Errorf("marshaling %s: %w", id, err)
+			}
+			if err := os.WriteFile(filepath.Join(outDir, id+".json"), data, 0o644); err != nil {
+				return fmt.Errorf("writing %s: %w", id, err)
+			}
+		}
+	}
+	return nil
+}
+
+// loadOfflineDump reads every *.json file in dir as a Vulnerability record,
+// the layout produced by a prefetched OSV.dev ecosystem dump.
+func loadOfflineDump(dir string) ([]Vulnerability, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading offline dump %s: %w", dir, err)
+	}
+
+	var vulns []Vulnerability
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		var vuln Vulnerability
+		if err := json.Unmarshal(data, &vuln); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", e.Name(), err)
+		}
+		vulns = append(vulns, vuln)
+	}
+	return vulns, nil
+}
+
+// findOffline brute-force matches every module against every vulnerability
+// in vulns, the offline counterpart to findOnline's two-step OSV.dev query.
+func findOffline(modules []Module, positions map[string]token.Position, vulns []Vulnerability) []Finding {
+	var findings []Finding
+	for _, m := range modules {
+		for _, vuln := range vulns {
+			if f, ok := toFinding(m, vuln, positions[m.Path]); ok {
+				findings = append(findings, f)
+			}
+		}
+	}
+	return findings
+}
+
+// toFinding checks whether vuln affects m and, if so, builds a Finding at
+// pos.
+func toFinding(m Module, vuln Vulnerability, pos token.Position) (Finding, bool) {
+	fixed, ok := affectedVersion(m, vuln)
+	if !ok {
+		return Finding{}, false
+	}
+	return Finding{
+		Module:       m,
+		VulnID:       vuln.ID,
+		Aliases:      vuln.Aliases,
+		Summary:      summaryOf(vuln),
+		FixedVersion: fixed,
+		References:   referenceURLs(vuln),
+		Pos:          pos,
+	}, true
+}
+
+func summaryOf(vuln Vulnerability) string {
+	if vuln.Summary != "" {
+		return vuln.Summary
+	}
+	return vuln.Details
+}
+
+func referenceURLs(vuln Vulnerability) []string {
+	urls := make([]string, len(vuln.References))
+	for i, r := range vuln.References {
+		urls[i] = r.URL
+	}
+	return urls
+}
+
+// affectedVersion reports whether vuln affects m.Path@m.Version and, if so,
+// the version it's fixed in (empty if unknown or unfixed). It only honors
+// SEMVER-type ranges: conservative over ECOSYSTEM or GIT ranges, whose
+// ordering this package has no way to evaluate.
+func affectedVersion(m Module, vuln Vulnerability) (fixedVersion string, affectedOK bool) {
+	v := semverOf(m.Version)
+	if v == "" {
+		return "", false
+	}
+
+	for _, a := range vuln.Affected {
+		if a.Package.Ecosystem != osvEcosystem || a.Package.Name != m.Path {
+			continue
+		}
+		for _, r := range a.Ranges {
+			if r.Type != "SEMVER" {
+				continue
+			}
+			if affected, fixed := evalRange(v, r.Events); affected {
+				return fixed, true
+			}
+		}
+		for _, ver := range a.Versions {
+			if ver == m.Version {
+				return "", true
+			}
+		}
+	}
+	return "", false
+}
+
+// evalRange walks a SEMVER range's ordered events and reports whether v
+// falls within an [introduced, fixed) or [introduced, last_affected] span.
+func evalRange(v string, events []event) (affectedRange bool, fixedVersion string) {
+	introduced := false
+	for _, e := range events {
+		switch {
+		case e.Introduced != "":
+			in := semverOf(e.Introduced)
+			introduced = in == "" || semver.Compare(v, in) >= 0
+		case e.Fixed != "":
+			if introduced {
+				fixed := semverOf(e.Fixed)
+				if fixed != "" && semver.Compare(v, fixed) < 0 {
+					return true, e.Fixed
+				}
+				introduced = false
+			}
+		case e.LastAffected != "":
+			if introduced {
+				last := semverOf(e.LastAffected)
+				if last != "" && semver.Compare(v, last) <= 0 {
+					return true, ""
+				}
+				introduced = false
+			}
+		}
+	}
+	return introduced, ""
+}
+
+// semverOf normalizes a Go module version (which already starts with "v")
+// or an OSV range event version (which may not) to the "vX.Y.Z" form
+// golang.org/x/mod/semver expects, returning "" if it's not valid semver.
+func semverOf(v string) string {
+	if v == "" {
+		return ""
+	}
+	if !strings.HasPrefix(v, "v") {
+		v = "v" + v
+	}
+	if !semver.IsValid(v) {
+		return ""
+	}
+	return v
+}
@@ -0,0 +1,48 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+)
+
+func init() {
+	Register("json", jsonFormatter{})
+}
+
+// jsonFormatter renders findings as a flat JSON array, one object per
+// finding, regardless of which Analyzer produced it.
+type jsonFormatter struct{}
+
+// jsonFinding is the wire representation of an analyzer.Finding.
+type jsonFinding struct {
+	RuleID      string `json:"ruleId"`
+	Severity    string `json:"severity"`
+	Message     string `json:"message"`
+	File        string `json:"file"`
+	StartLine   int    `json:"startLine"`
+	StartColumn int    `json:"startColumn"`
+	EndLine     int    `json:"endLine"`
+	EndColumn   int    `json:"endColumn"`
+}
+
+func (jsonFormatter) Format(w io.Writer, runs []Run) error {
+	var out []jsonFinding
+	for _, run := range runs {
+		for _, f := range run.Findings {
+			out = append(out, jsonFinding{
+				RuleID:      f.RuleID,
+				Severity:    f.Severity.String(),
+				Message:     f.Message,
+				File:        f.Pos.Filename,
+				StartLine:   f.Pos.Line,
+				StartColumn: f.Pos.Column,
+				EndLine:     f.EndPos.Line,
+				EndColumn:   f.EndPos.Column,
+			})
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
@@ -0,0 +1,35 @@
+// Package report renders analyzer findings through pluggable output
+// formatters, the same registry-by-name pattern the analyzer package uses
+// for checkers.
+package report
+
+import (
+	"io"
+
+	"github.com/josephvolmer/yavs/analyzer"
+)
+
+// Run groups the findings produced by a single Analyzer invocation.
+type Run struct {
+	Analyzer analyzer.Analyzer
+	Findings []analyzer.Finding
+}
+
+// Formatter renders a set of Runs to w.
+type Formatter interface {
+	Format(w io.Writer, runs []Run) error
+}
+
+var formatters = map[string]Formatter{}
+
+// Register adds a Formatter under name. Formatters call this from an init
+// function of the file that implements them.
+func Register(name string, f Formatter) {
+	formatters[name] = f
+}
+
+// Lookup returns the Formatter registered under name, if any.
+func Lookup(name string) (Formatter, bool) {
+	f, ok := formatters[name]
+	return f, ok
+}
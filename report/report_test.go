@@ -0,0 +1,111 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"go/ast"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/josephvolmer/yavs/analyzer"
+)
+
+type fakeAnalyzer struct{ meta analyzer.Metadata }
+
+func (f fakeAnalyzer) Metadata() analyzer.Metadata                      { return f.meta }
+func (fakeAnalyzer) Check(*ast.File, *token.FileSet) []analyzer.Finding { return nil }
+
+func testRuns() []Run {
+	meta := analyzer.Metadata{
+		ID:          "YAVS-TEST-001",
+		Title:       "Test rule",
+		Description: "A rule used only by report's own tests.",
+		Severity:    analyzer.High,
+	}
+	finding := analyzer.Finding{
+		RuleID:   meta.ID,
+		Severity: meta.Severity,
+		Message:  "something tainted reached a sink",
+		Pos:      token.Position{Filename: "fixture.go", Line: 3, Column: 2},
+		EndPos:   token.Position{Filename: "fixture.go", Line: 3, Column: 10},
+	}
+	return []Run{{
+		Analyzer: fakeAnalyzer{meta: meta},
+		Findings: []analyzer.Finding{finding},
+	}}
+}
+
+// TestTextFormatterFormat asserts the one-line-per-finding shape the yavs
+// CLI has always printed to stdout.
+func TestTextFormatterFormat(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (textFormatter{}).Format(&buf, testRuns()); err != nil {
+		t.Fatalf("Format: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{"fixture.go:3:2", "YAVS-TEST-001", "HIGH", "something tainted reached a sink"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output %q missing %q", out, want)
+		}
+	}
+}
+
+// TestJSONFormatterFormat asserts the flat JSON array shape, one object per
+// finding regardless of which Analyzer produced it.
+func TestJSONFormatterFormat(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (jsonFormatter{}).Format(&buf, testRuns()); err != nil {
+		t.Fatalf("Format: %v", err)
+	}
+
+	var out []jsonFinding
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("Unmarshal: %v\noutput: %s", err, buf.String())
+	}
+	if len(out) != 1 {
+		t.Fatalf("got %d findings, want 1: %+v", len(out), out)
+	}
+	if out[0].RuleID != "YAVS-TEST-001" || out[0].Severity != "HIGH" || out[0].File != "fixture.go" {
+		t.Errorf("unexpected finding shape: %+v", out[0])
+	}
+}
+
+// TestSARIFFormatterFormat asserts the SARIF 2.1.0 log shape: one run per
+// Analyzer invocation with its rule described in tool.driver.rules.
+func TestSARIFFormatterFormat(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (sarifFormatter{}).Format(&buf, testRuns()); err != nil {
+		t.Fatalf("Format: %v", err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("Unmarshal: %v\noutput: %s", err, buf.String())
+	}
+	if log.Version != sarifVersion {
+		t.Errorf("Version = %q, want %q", log.Version, sarifVersion)
+	}
+	if len(log.Runs) != 1 {
+		t.Fatalf("got %d runs, want 1", len(log.Runs))
+	}
+	run := log.Runs[0]
+	if len(run.Tool.Driver.Rules) != 1 || run.Tool.Driver.Rules[0].ID != "YAVS-TEST-001" {
+		t.Errorf("unexpected rules: %+v", run.Tool.Driver.Rules)
+	}
+	if len(run.Results) != 1 || run.Results[0].RuleID != "YAVS-TEST-001" || run.Results[0].Level != "error" {
+		t.Errorf("unexpected results: %+v", run.Results)
+	}
+}
+
+// TestLookupReturnsRegisteredFormatters is a regression test for the
+// registry itself: each formatter's init function must have registered it
+// under its expected name by the time Lookup is called.
+func TestLookupReturnsRegisteredFormatters(t *testing.T) {
+	for _, name := range []string{"text", "json", "sarif"} {
+		if _, ok := Lookup(name); !ok {
+			t.Errorf("Lookup(%q) not found", name)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/josephvolmer/yavs/analyzer"
+)
+
+func init() {
+	Register("sarif", sarifFormatter{})
+}
+
+const (
+	sarifSchema   = "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json"
+	sarifVersion  = "2.1.0"
+	sarifToolName = "yavs"
+)
+
+// sarifFormatter renders findings as a SARIF 2.1.0 log, with one run per
+// Analyzer invocation so that each run's tool.driver.rules describes exactly
+// the rule that run can produce.
+type sarifFormatter struct{}
+
+func (sarifFormatter) Format(w io.Writer, runs []Run) error {
+	log := sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    make([]sarifRun, 0, len(runs)),
+	}
+
+	for _, run := range runs {
+		meta := run.Analyzer.Metadata()
+		sRun := sarifRun{
+			Tool: sarifTool{
+				Driver: sarifDriver{
+					Name:  sarifToolName,
+					Rules: []sarifRule{sarifRuleFromMetadata(meta)},
+				},
+			},
+			Results: make([]sarifResult, 0, len(run.Findings)),
+		}
+		for _, f := range run.Findings {
+			sRun.Results = append(sRun.Results, sarifResultFromFinding(f))
+		}
+		log.Runs = append(log.Runs, sRun)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(log)
+}
+
+func sarifRuleFromMetadata(m analyzer.Metadata) sarifRule {
+	return sarifRule{
+		ID:               m.ID,
+		Name:             m.Title,
+		ShortDescription: sarifMessage{Text: m.Title},
+		FullDescription:  sarifMessage{Text: m.Description},
+		HelpURI:          m.HelpURI,
+		DefaultConfiguration: sarifConfiguration{
+			Level: sarifLevel(m.Severity),
+		},
+	}
+}
+
+func sarifResultFromFinding(f analyzer.Finding) sarifResult {
+	return sarifResult{
+		RuleID:  f.RuleID,
+		Level:   sarifLevel(f.Severity),
+		Message: sarifMessage{Text: f.Message},
+		Locations: []sarifLocation{{
+			PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: f.Pos.Filename},
+				Region: sarifRegion{
+					StartLine:   f.Pos.Line,
+					StartColumn: f.Pos.Column,
+					EndLine:     f.EndPos.Line,
+					EndColumn:   f.EndPos.Column,
+				},
+			},
+		}},
+	}
+}
+
+// sarifLevel maps yavs severities onto the SARIF result/rule levels
+// (https://docs.oasis-open.org/sarif/sarif/v2.1.0): note, warning, or error.
+func sarifLevel(s analyzer.Severity) string {
+	switch s {
+	case analyzer.Low:
+		return "note"
+	case analyzer.Medium:
+		return "warning"
+	default:
+		return "error"
+	}
+}
+
+// The following types are a minimal mapping of the subset of the SARIF
+// 2.1.0 object model yavs produces; see
+// https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html.
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	Name                 string             `json:"name,omitempty"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	FullDescription      sarifMessage       `json:"fullDescription"`
+	HelpURI              string             `json:"helpUri,omitempty"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+	EndLine     int `json:"endLine,omitempty"`
+	EndColumn   int `json:"endColumn,omitempty"`
+}
@@ -0,0 +1,25 @@
+package report
+
+import (
+	"fmt"
+	"io"
+)
+
+func init() {
+	Register("text", textFormatter{})
+}
+
+// textFormatter renders findings as one line per finding, in the format the
+// yavs CLI has always printed to stdout.
+type textFormatter struct{}
+
+func (textFormatter) Format(w io.Writer, runs []Run) error {
+	for _, run := range runs {
+		for _, f := range run.Findings {
+			if _, err := fmt.Fprintf(w, "%s: [%s] %s: %s\n", f.Pos, f.RuleID, f.Severity, f.Message); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package secrets
+
+import (
+	"math"
+	"strings"
+)
+
+// shannonEntropy returns s's Shannon entropy in bits per character.
+func shannonEntropy(s string) float64 {
+	if s == "" {
+		return 0
+	}
+	var counts [256]int
+	for i := 0; i < len(s); i++ {
+		counts[s[i]]++
+	}
+
+	n := float64(len(s))
+	entropy := 0.0
+	for _, c := range counts {
+		if c == 0 {
+			continue
+		}
+		p := float64(c) / n
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
+const (
+	base64Charset = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/="
+	hexCharset    = "0123456789abcdefABCDEF"
+)
+
+// looksEncoded reports whether every character in s belongs to the base64
+// or hex alphabet, the two encodings a high-entropy secret is typically
+// rendered in.
+func looksEncoded(s string) bool {
+	return allCharsIn(s, base64Charset) || allCharsIn(s, hexCharset)
+}
+
+func allCharsIn(s, charset string) bool {
+	for i := 0; i < len(s); i++ {
+		if !strings.ContainsRune(charset, rune(s[i])) {
+			return false
+		}
+	}
+	return true
+}
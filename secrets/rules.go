@@ -0,0 +1,152 @@
+package secrets
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Rule is a single named check applied to a candidate string. A rule may
+// test a regex Pattern, a minimum Shannon entropy (EntropyMin), or both; at
+// least one must be set or the rule never matches. KeywordsNear, if
+// non-empty, additionally requires one of those words to appear within
+// keywordProximityLines of the match before it's reported. If SameLineOnly
+// is set, the requirement is stricter still: one of those words must name
+// what the candidate was actually assigned to (its bound identifier or
+// struct field key — see boundNames), not merely appear somewhere on its
+// line — for a pattern broad enough to match ordinary short strings, that
+// keeps the rule from firing on every incidental mention of a keyword
+// elsewhere on the line rather than an actual credential assignment.
+// Message overrides the default pattern/entropy wording when set, for
+// rules (like KEYWORD_ADJACENT_CREDENTIAL) whose pattern alone doesn't
+// describe why the match is suspicious.
+type Rule struct {
+	ID           string
+	Pattern      *regexp.Regexp
+	EntropyMin   float64
+	KeywordsNear []string
+	SameLineOnly bool
+	Message      string
+}
+
+// Rules is the active ruleset checked against every candidate string.
+// LoadRulesFile extends it with rules loaded from a YAML file.
+var Rules = builtinRules()
+
+// builtinRules returns the named regex rules for well-known credential
+// formats, plus the generic high-entropy check for secrets with no
+// recognizable prefix.
+func builtinRules() []Rule {
+	return []Rule{
+		{ID: "AWS_ACCESS_KEY_ID", Pattern: regexp.MustCompile(`AKIA[0-9A-Z]{16}`)},
+		{ID: "GITHUB_TOKEN", Pattern: regexp.MustCompile(`ghp_[A-Za-z0-9]{36}`)},
+		{ID: "SLACK_TOKEN", Pattern: regexp.MustCompile(`xox[baprs]-[A-Za-z0-9-]+`)},
+		{ID: "GENERIC_SECRET_KEY", Pattern: regexp.MustCompile(`sk-[A-Za-z0-9]{20,}`)},
+		{ID: "JWT", Pattern: regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+`)},
+		{ID: "PRIVATE_KEY_PEM", Pattern: regexp.MustCompile(`-----BEGIN (RSA |EC |OPENSSH |DSA )?PRIVATE KEY-----`)},
+		{ID: "HIGH_ENTROPY_STRING", EntropyMin: 4.5},
+		{
+			ID:           "KEYWORD_ADJACENT_CREDENTIAL",
+			Pattern:      regexp.MustCompile(`^.{4,19}$`),
+			KeywordsNear: []string{"password", "passwd", "pwd", "secret", "apikey", "api_key", "token", "credential"},
+			SameLineOnly: true,
+			Message:      "KEYWORD_ADJACENT_CREDENTIAL: short string literal assigned near a credential-related keyword",
+		},
+	}
+}
+
+// ruleFile is the YAML shape of a rules.yaml document.
+type ruleFile struct {
+	Rules []rawRule `yaml:"rules"`
+}
+
+type rawRule struct {
+	ID           string   `yaml:"id"`
+	Pattern      string   `yaml:"pattern"`
+	EntropyMin   float64  `yaml:"entropy_min"`
+	KeywordsNear []string `yaml:"keywords_near"`
+	SameLineOnly bool     `yaml:"same_line_only"`
+	Message      string   `yaml:"message"`
+}
+
+// LoadRulesFile parses path as a rules.yaml document and appends its rules
+// to Rules.
+func LoadRulesFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	var parsed ruleFile
+	if err := yaml.Unmarshal(data, &parsed); err != nil {
+		return fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	for _, raw := range parsed.Rules {
+		rule := Rule{ID: raw.ID, EntropyMin: raw.EntropyMin, KeywordsNear: raw.KeywordsNear, SameLineOnly: raw.SameLineOnly, Message: raw.Message}
+		if raw.Pattern != "" {
+			re, err := regexp.Compile(raw.Pattern)
+			if err != nil {
+				return fmt.Errorf("rule %s: %w", raw.ID, err)
+			}
+			rule.Pattern = re
+		}
+		Rules = append(Rules, rule)
+	}
+	return nil
+}
+
+// matches reports whether value satisfies r's pattern and entropy check,
+// whichever of the two are set; a rule with neither never matches.
+func (r Rule) matches(value string) bool {
+	if r.Pattern == nil && r.EntropyMin == 0 {
+		return false
+	}
+	if r.Pattern != nil && !r.Pattern.MatchString(value) {
+		return false
+	}
+	if r.EntropyMin > 0 {
+		if len(value) < 20 || shannonEntropy(value) < r.EntropyMin || !looksEncoded(value) {
+			return false
+		}
+	}
+	return true
+}
+
+// keywordSatisfied reports whether r's KeywordsNear requirement is met for
+// a candidate bound to boundName ("" if no name could be determined). An
+// empty KeywordsNear list always matches. A SameLineOnly rule requires a
+// keyword to appear in boundName itself: scanning the surrounding line
+// text instead would also match a keyword-named constant like GITHUB_TOKEN
+// or a keyword list like []string{"token", "secret"}, neither of which is
+// actually a credential assignment. Other rules fall back to a textual
+// search across the lines within keywordProximityLines, since they aren't
+// scoped tightly enough to need boundName.
+func (r Rule) keywordSatisfied(boundName string, lines []string, line int) bool {
+	if len(r.KeywordsNear) == 0 {
+		return true
+	}
+	if r.SameLineOnly {
+		lower := strings.ToLower(boundName)
+		for _, kw := range r.KeywordsNear {
+			if strings.Contains(lower, strings.ToLower(kw)) {
+				return true
+			}
+		}
+		return false
+	}
+	return keywordNearby(lines, line, keywordProximityLines, r.KeywordsNear)
+}
+
+func (r Rule) message() string {
+	if r.Message != "" {
+		return r.Message
+	}
+	if r.Pattern != nil {
+		return fmt.Sprintf("%s: value matches a known secret pattern", r.ID)
+	}
+	return fmt.Sprintf("%s: high-entropy string resembles an encoded secret", r.ID)
+}
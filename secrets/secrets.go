@@ -0,0 +1,266 @@
+// Package secrets detects hardcoded credentials: both well-known formats
+// (cloud provider keys, GitHub/Slack tokens, JWTs, PEM private keys) via
+// named regexes, and unlabeled high-entropy strings via a Shannon-entropy
+// and character-distribution check. Its Analyzer scans Go string literals
+// through the same analyzer.Registry as the other checkers; ScanFile and
+// ScanDir additionally cover non-Go files the AST pass can't see.
+package secrets
+
+import (
+	"bufio"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/josephvolmer/yavs/analyzer"
+)
+
+// RuleID identifies every finding this package produces.
+const RuleID = "YAVS-SECRET-001"
+
+// RuleMetadata describes this package's rule for report formatters such as
+// the SARIF writer.
+var RuleMetadata = analyzer.Metadata{
+	ID:          RuleID,
+	Title:       "Hardcoded secret or credential",
+	Description: "A string literal matches a known secret pattern (cloud provider key, token, private key) or has the high entropy and character distribution typical of an encoded credential.",
+	HelpURI:     "https://github.com/josephvolmer/yavs/wiki/YAVS-SECRET-001",
+	Severity:    analyzer.High,
+}
+
+// Analyzer scans Go string literals against Rules.
+type Analyzer struct{}
+
+func init() {
+	analyzer.Register(Analyzer{})
+}
+
+// Metadata implements analyzer.Analyzer.
+func (Analyzer) Metadata() analyzer.Metadata { return RuleMetadata }
+
+// Check implements analyzer.Analyzer.
+func (Analyzer) Check(file *ast.File, fset *token.FileSet) []analyzer.Finding {
+	lines := sourceLines(fset, file)
+	names := boundNames(file)
+
+	imports := map[*ast.BasicLit]bool{}
+	for _, imp := range file.Imports {
+		imports[imp.Path] = true
+	}
+
+	var findings []analyzer.Finding
+	ast.Inspect(file, func(n ast.Node) bool {
+		lit, ok := n.(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING || imports[lit] {
+			return true
+		}
+		value, err := strconv.Unquote(lit.Value)
+		if err != nil {
+			return true
+		}
+
+		pos := fset.Position(lit.Pos())
+		for _, msg := range matchRules(value, names[lit], lines, pos.Line) {
+			findings = append(findings, analyzer.Finding{
+				RuleID:   RuleID,
+				Severity: RuleMetadata.Severity,
+				Message:  msg,
+				Pos:      pos,
+				EndPos:   fset.Position(lit.End()),
+			})
+		}
+		return true
+	})
+	return findings
+}
+
+// boundNames maps each string literal in file to the name it's actually
+// assigned to: a const/var spec's name, an assignment's left-hand
+// identifier, or a struct literal field's key. A literal with no
+// determinable name (e.g. a bare element of a slice literal like
+// []string{"token", "secret"}) is absent from the map. KEYWORD_ADJACENT_
+// CREDENTIAL uses this to require a keyword to name what a literal was
+// assigned to, rather than merely appear somewhere on its line.
+func boundNames(file *ast.File) map[*ast.BasicLit]string {
+	names := map[*ast.BasicLit]string{}
+	bind := func(name string, expr ast.Expr) {
+		if lit, ok := expr.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+			names[lit] = name
+		}
+	}
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.ValueSpec:
+			for i, name := range n.Names {
+				if i < len(n.Values) {
+					bind(name.Name, n.Values[i])
+				}
+			}
+		case *ast.AssignStmt:
+			for i, lhs := range n.Lhs {
+				if id, ok := lhs.(*ast.Ident); ok && i < len(n.Rhs) {
+					bind(id.Name, n.Rhs[i])
+				}
+			}
+		case *ast.KeyValueExpr:
+			switch key := n.Key.(type) {
+			case *ast.Ident:
+				bind(key.Name, n.Value)
+			case *ast.BasicLit:
+				// A map literal keyed by a string, e.g.
+				// map[string]string{"password": "..."}: the key itself is
+				// the name the value is bound to.
+				if keyName, err := strconv.Unquote(key.Value); err == nil {
+					bind(keyName, n.Value)
+				}
+			}
+		}
+		return true
+	})
+	return names
+}
+
+const keywordProximityLines = 3
+
+// matchRules returns one message per Rule in Rules that value satisfies.
+// boundName is the name value was actually assigned to, if one could be
+// determined ("" otherwise); lines and line supply the textual proximity
+// search a non-SameLineOnly rule's KeywordsNear falls back to.
+func matchRules(value, boundName string, lines []string, line int) []string {
+	var messages []string
+	for _, rule := range Rules {
+		if !rule.matches(value) {
+			continue
+		}
+		if !rule.keywordSatisfied(boundName, lines, line) {
+			continue
+		}
+		messages = append(messages, rule.message())
+	}
+	return messages
+}
+
+// keywordNearby reports whether one of keywords appears, case-insensitively,
+// within window lines of line. An empty keywords list always matches; a nil
+// lines slice (source unavailable) only matches when no keywords were
+// required.
+func keywordNearby(lines []string, line, window int, keywords []string) bool {
+	if len(keywords) == 0 {
+		return true
+	}
+	if lines == nil {
+		return false
+	}
+
+	lo := line - 1 - window
+	if lo < 0 {
+		lo = 0
+	}
+	hi := line + window
+	if hi > len(lines) {
+		hi = len(lines)
+	}
+
+	for i := lo; i < hi; i++ {
+		lower := strings.ToLower(lines[i])
+		for _, kw := range keywords {
+			if strings.Contains(lower, strings.ToLower(kw)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// sourceLines reads file's backing source so Check can evaluate
+// KeywordsNear proximity. It returns nil if the source can't be read, e.g.
+// a file parsed from an in-memory buffer rather than disk.
+func sourceLines(fset *token.FileSet, file *ast.File) []string {
+	name := fset.Position(file.Pos()).Filename
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil
+	}
+	return strings.Split(string(data), "\n")
+}
+
+// lineKeyName extracts the portion of line before its first '=' or ':', the
+// conventional separator between a name and its value in KEY=VALUE env
+// files and YAML-style config. It's ScanFile's substitute for boundNames,
+// which needs a real Go AST to work from. Returns "" if neither separator
+// is present, rather than falling back to the whole line: without a
+// separator there's no name to check a keyword against, only a value.
+func lineKeyName(line string) string {
+	if i := strings.IndexAny(line, "=:"); i >= 0 {
+		return line[:i]
+	}
+	return ""
+}
+
+// ScanFile applies Rules to each line of path, for files outside the Go AST
+// (config, .env, shell scripts) that Check can't see. Unlike Check it has
+// no notion of string literals, so rules run against whole lines.
+func ScanFile(path string) ([]analyzer.Finding, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	var findings []analyzer.Finding
+	for i, line := range lines {
+		for _, msg := range matchRules(line, lineKeyName(line), lines, i+1) {
+			pos := token.Position{Filename: path, Line: i + 1, Column: 1}
+			findings = append(findings, analyzer.Finding{
+				RuleID:   RuleID,
+				Severity: RuleMetadata.Severity,
+				Message:  msg,
+				Pos:      pos,
+				EndPos:   pos,
+			})
+		}
+	}
+	return findings, nil
+}
+
+// ScanDir walks root and calls ScanFile on every non-Go file, returning the
+// combined findings. Go source is covered by Check via the analyzer
+// registry, so it's skipped here to avoid reporting each match twice.
+func ScanDir(root string) ([]analyzer.Finding, error) {
+	var findings []analyzer.Finding
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || strings.HasSuffix(path, ".go") {
+			return nil
+		}
+		// Best-effort: an unreadable or binary file (e.g. a line with no
+		// newline for 1MB) shouldn't abort scanning the rest of the tree.
+		fileFindings, err := ScanFile(path)
+		if err != nil {
+			return nil
+		}
+		findings = append(findings, fileFindings...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return findings, nil
+}
@@ -0,0 +1,249 @@
+package taint
+
+import (
+	"fmt"
+	"go/token"
+
+	"golang.org/x/tools/go/callgraph"
+	"golang.org/x/tools/go/ssa"
+	"golang.org/x/tools/go/ssa/ssautil"
+)
+
+// engine runs a context-insensitive, whole-program worklist over SSA
+// values: a value is tainted once any instruction that produces it has a
+// tainted operand, per the propagation rules in mark. This deliberately
+// over-approximates (the key invariant called for by this package): it may
+// report a few more paths than are reachable in practice, but it will not
+// miss a real one because a value was refactored into a helper function.
+type engine struct {
+	prog *ssa.Program
+	cg   *callgraph.Graph
+
+	tainted  map[ssa.Value][]PathStep
+	worklist []ssa.Value
+	findings []Finding
+	reported map[string]bool // dedup key: sink position + message
+}
+
+func newEngine(prog *ssa.Program, cg *callgraph.Graph) *engine {
+	return &engine{
+		prog:     prog,
+		cg:       cg,
+		tainted:  map[ssa.Value][]PathStep{},
+		reported: map[string]bool{},
+	}
+}
+
+func (e *engine) run() []Finding {
+	for fn := range ssautil.AllFunctions(e.prog) {
+		e.seedSources(fn)
+	}
+	for len(e.worklist) > 0 {
+		v := e.worklist[len(e.worklist)-1]
+		e.worklist = e.worklist[:len(e.worklist)-1]
+		e.propagate(v)
+	}
+	return e.findings
+}
+
+// mark taints v with path, the chain of PathSteps leading to it, and queues
+// it for propagation if this is the first time v has been tainted.
+func (e *engine) mark(v ssa.Value, path []PathStep) {
+	if _, ok := e.tainted[v]; ok {
+		return
+	}
+	e.tainted[v] = path
+	e.worklist = append(e.worklist, v)
+}
+
+// seedSources marks the results of Source calls in fn as tainted.
+func (e *engine) seedSources(fn *ssa.Function) {
+	for _, b := range fn.Blocks {
+		for _, instr := range b.Instrs {
+			call, ok := instr.(*ssa.Call)
+			if !ok {
+				continue
+			}
+			pkg, name, ok := calleeInfo(call.Common())
+			if !ok || !matches(sources, pkg, name) {
+				continue
+			}
+			e.mark(call, []PathStep{{
+				Description: fmt.Sprintf("%s.%s reads request-controlled data", pkg, name),
+				Pos:         e.prog.Fset.Position(call.Pos()),
+			}})
+		}
+	}
+}
+
+// propagate inspects every instruction that reads the tainted value v and
+// extends taint to whatever that instruction produces, per the rules
+// described in the package doc comment.
+func (e *engine) propagate(v ssa.Value) {
+	path := e.tainted[v]
+	refs := v.Referrers()
+	if refs == nil {
+		return
+	}
+
+	for _, instr := range *refs {
+		switch instr := instr.(type) {
+		case *ssa.Phi:
+			e.mark(instr, e.step(path, "merges with another value", instr.Pos()))
+		case *ssa.IndexAddr:
+			e.mark(instr, e.step(path, "indexes into a tainted collection", instr.Pos()))
+		case *ssa.Field:
+			e.mark(instr, e.step(path, "reads a field of a tainted value", instr.Pos()))
+		case *ssa.FieldAddr:
+			e.mark(instr, e.step(path, "reads a field of a tainted value", instr.Pos()))
+		case *ssa.Convert:
+			if isTemplateHTMLConversion(instr) {
+				e.reportSink(path, instr.Pos(), "conversion to html/template.HTML bypasses contextual auto-escaping")
+				continue
+			}
+			e.mark(instr, e.step(path, "is converted to another type", instr.Pos()))
+		case *ssa.MakeInterface:
+			// A variadic call (fmt.Sprintf, fmt.Fprintf, exec.Command, ...)
+			// boxes each non-interface argument through MakeInterface before
+			// storing it into the backing array the SSA builder synthesizes
+			// for the "...interface{}" parameter.
+			e.mark(instr, e.step(path, "is boxed into an interface value", instr.Pos()))
+		case *ssa.Store:
+			// Most Stores through a tainted value are writes into the
+			// backing array of a variadic call's argument slice: the
+			// destination is an IndexAddr into that array, so the array
+			// itself (not the per-element pointer) is what later reaches
+			// the call via a Slice instruction.
+			target := instr.Addr
+			if idx, ok := target.(*ssa.IndexAddr); ok {
+				target = idx.X
+			}
+			e.mark(target, e.step(path, "is written into a tainted location", instr.Pos()))
+		case *ssa.Slice:
+			e.mark(instr, e.step(path, "is sliced from a tainted array", instr.Pos()))
+		case *ssa.Call:
+			e.propagateThroughCall(v, path, instr)
+		case *ssa.Return:
+			e.propagateThroughReturn(path, instr)
+		}
+	}
+}
+
+func (e *engine) propagateThroughCall(v ssa.Value, path []PathStep, call *ssa.Call) {
+	common := call.Common()
+
+	if isFprintfToResponseWriter(common) && argIndex(common.Args[1:], v) >= 0 {
+		e.reportSink(path, call.Pos(), "fmt.Fprintf writes tainted data to an http.ResponseWriter without escaping")
+		return
+	}
+
+	if pkg, name, ok := calleeInfo(common); ok {
+		if matches(sinks, pkg, name) && argIndex(common.Args, v) >= 0 {
+			e.reportSink(path, call.Pos(), fmt.Sprintf("tainted data reaches %s.%s", pkg, name))
+			return
+		}
+		if matches(sanitizers, pkg, name) {
+			return // taint stops here
+		}
+	}
+
+	if common.IsInvoke() {
+		// Conservative over-approximation: an unresolved interface call
+		// can't be followed into its implementation, so treat both its
+		// result and its receiver as tainted passthrough.
+		e.mark(common.Value, e.step(path, "flows into an interface method receiver", call.Pos()))
+		if call.Type() != nil {
+			e.mark(call, e.step(path, "returns from an unresolved interface call", call.Pos()))
+		}
+		return
+	}
+
+	callee := common.StaticCallee()
+	if callee == nil {
+		return
+	}
+	if len(callee.Blocks) == 0 || isStdlibFunction(callee) {
+		// Either callee has no SSA body to step into, or it's a standard
+		// library function like fmt.Sprintf whose own implementation routes
+		// arguments through reflection in ways propagateThroughReturn can't
+		// follow. Taint its result conservatively rather than losing the
+		// path, since we can't see (or choose not to trace) what it does
+		// with the tainted argument. User code instead gets traced
+		// precisely below, through its actual Return statements, so a
+		// helper that merely touches a tainted argument without deriving
+		// its result from it isn't reported as a false positive.
+		if call.Type() != nil {
+			e.mark(call, e.step(path, "returns from "+callee.Name(), call.Pos()))
+		}
+		return
+	}
+	if i := argIndex(common.Args, v); i >= 0 && i < len(callee.Params) {
+		e.mark(callee.Params[i], e.step(path, "is passed into "+callee.Name(), call.Pos()))
+	}
+}
+
+// propagateThroughReturn extends taint to every call site of ret's enclosing
+// function: one of that function's return values derives from a tainted
+// value, so the call expression at each of its call sites is tainted too.
+func (e *engine) propagateThroughReturn(path []PathStep, ret *ssa.Return) {
+	fn := ret.Parent()
+	node := e.cg.Nodes[fn]
+	if node == nil {
+		return
+	}
+	for _, edge := range node.In {
+		call, ok := edge.Site.(*ssa.Call)
+		if !ok || call.Type() == nil {
+			continue
+		}
+		e.mark(call, e.step(path, "returns from "+fn.Name(), call.Pos()))
+	}
+}
+
+// step appends a new PathStep at pos to path, returning the extended path.
+func (e *engine) step(path []PathStep, description string, pos token.Pos) []PathStep {
+	next := make([]PathStep, len(path), len(path)+1)
+	copy(next, path)
+	return append(next, PathStep{Description: description, Pos: e.prog.Fset.Position(pos)})
+}
+
+// reportSink records a Finding ending at pos with message, deduplicating on
+// the combination of the source path and the sink location.
+func (e *engine) reportSink(path []PathStep, pos token.Pos, message string) {
+	full := e.step(path, message, pos)
+	sink := full[len(full)-1]
+
+	key := fmt.Sprintf("%s|%s", sink.Pos, message)
+	if e.reported[key] {
+		return
+	}
+	e.reported[key] = true
+
+	e.findings = append(e.findings, Finding{
+		Message: describePath(full),
+		Path:    full,
+	})
+}
+
+// describePath renders a taint path as a single human-readable message,
+// e.g. "r.FormValue reads request-controlled data -> is passed into
+// buildQuery -> tainted data reaches database/sql.Query".
+func describePath(path []PathStep) string {
+	msg := ""
+	for i, s := range path {
+		if i > 0 {
+			msg += " -> "
+		}
+		msg += s.Description
+	}
+	return msg
+}
+
+func argIndex(args []ssa.Value, v ssa.Value) int {
+	for i, a := range args {
+		if a == v {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,115 @@
+package taint
+
+import (
+	"strings"
+
+	"golang.org/x/tools/go/ssa"
+)
+
+// underlyingType follows a chain of interface-to-interface conversions back
+// to the value that was actually passed in, e.g. the *ssa.ChangeInterface
+// the builder inserts when an http.ResponseWriter argument is widened to
+// the io.Writer parameter type fmt.Fprintf declares. Without this, Type()
+// on the call's argument always reports the narrower declared parameter
+// type rather than what the caller holds.
+func underlyingType(v ssa.Value) string {
+	for {
+		switch x := v.(type) {
+		case *ssa.ChangeInterface:
+			v = x.X
+		case *ssa.MakeInterface:
+			v = x.X
+		default:
+			return v.Type().String()
+		}
+	}
+}
+
+// callPattern identifies a function by its package import path and short
+// name, as returned by ssa.Function.Name() (a method's receiver is not part
+// of the name, so "(*Request).FormValue" is matched as pkg "net/http", name
+// "FormValue").
+type callPattern struct {
+	Pkg  string
+	Name string
+}
+
+// sources are calls whose result originates from the incoming HTTP request.
+var sources = []callPattern{
+	{"net/http", "FormValue"},
+	{"net/http", "PostFormValue"},
+	{"net/url", "Get"},  // (url.Values).Get, reached via r.URL.Query().Get(...)
+	{"net/http", "Get"}, // (http.Header).Get, reached via r.Header.Get(...)
+}
+
+// sinks are calls where tainted data becomes dangerous.
+var sinks = []callPattern{
+	{"database/sql", "Query"},
+	{"database/sql", "QueryContext"},
+	{"database/sql", "QueryRow"},
+	{"database/sql", "QueryRowContext"},
+	{"database/sql", "Exec"},
+	{"database/sql", "ExecContext"},
+	{"os/exec", "Command"},
+	{"os/exec", "CommandContext"},
+	{"io/ioutil", "ReadFile"},
+}
+
+// sanitizers are calls that consume tainted data and produce a value this
+// analysis considers clean, stopping further propagation.
+var sanitizers = []callPattern{
+	{"html", "EscapeString"},
+	{"strconv", "Atoi"},
+	{"path/filepath", "Clean"},
+}
+
+func matches(patterns []callPattern, pkg, name string) bool {
+	for _, p := range patterns {
+		if p.Pkg == pkg && p.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// calleeInfo returns the import path and name of a call's static callee, if
+// it can be determined without running the program.
+func calleeInfo(common *ssa.CallCommon) (pkg, name string, ok bool) {
+	fn := common.StaticCallee()
+	if fn == nil || fn.Pkg == nil || fn.Pkg.Pkg == nil {
+		return "", "", false
+	}
+	return fn.Pkg.Pkg.Path(), fn.Name(), true
+}
+
+// isStdlibFunction reports whether fn belongs to the Go standard library,
+// identified the conventional way: a standard library import path's first
+// segment never contains a dot, unlike a module path (e.g. "fmt", "os/exec"
+// vs "github.com/josephvolmer/yavs/taint").
+func isStdlibFunction(fn *ssa.Function) bool {
+	if fn.Pkg == nil || fn.Pkg.Pkg == nil {
+		return false
+	}
+	path := fn.Pkg.Pkg.Path()
+	if i := strings.IndexByte(path, '/'); i >= 0 {
+		path = path[:i]
+	}
+	return !strings.ContainsRune(path, '.')
+}
+
+// isFprintfToResponseWriter reports whether call is fmt.Fprintf writing to
+// an http.ResponseWriter, the reflected-XSS sink the AST-based
+// analyzer.Analyzer for this class also covers.
+func isFprintfToResponseWriter(common *ssa.CallCommon) bool {
+	pkg, name, ok := calleeInfo(common)
+	if !ok || pkg != "fmt" || name != "Fprintf" || len(common.Args) == 0 {
+		return false
+	}
+	return strings.Contains(underlyingType(common.Args[0]), "net/http.ResponseWriter")
+}
+
+// isTemplateHTMLConversion reports whether conv converts a value to
+// html/template.HTML, which bypasses html/template's contextual escaping.
+func isTemplateHTMLConversion(conv *ssa.Convert) bool {
+	return conv.Type().String() == "html/template.HTML"
+}
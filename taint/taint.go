@@ -0,0 +1,99 @@
+// Package taint performs interprocedural taint tracking across function
+// boundaries using golang.org/x/tools/go/ssa. It connects well-known HTTP
+// request accessors (a Source) to dangerous sinks (a Sink) through a
+// call-graph reachability analysis, stopping propagation at known
+// Sanitizer calls.
+//
+// Unlike the analyzer package's per-file checkers, taint analysis needs the
+// whole program's SSA form and type information, so it is driven separately
+// by cmd/yavs via Analyze rather than through the analyzer.Registry.
+package taint
+
+import (
+	"go/ast"
+	"go/token"
+
+	"golang.org/x/tools/go/callgraph/cha"
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/go/ssa"
+	"golang.org/x/tools/go/ssa/ssautil"
+
+	"github.com/josephvolmer/yavs/analyzer"
+)
+
+// RuleID identifies findings produced by this package in reports.
+const RuleID = "YAVS-TAINT-001"
+
+// RuleMetadata describes this package's single rule for report formatters
+// that key off analyzer.Metadata, such as the SARIF writer.
+var RuleMetadata = analyzer.Metadata{
+	ID:          RuleID,
+	Title:       "Tainted request data reaches a dangerous sink",
+	Description: "HTTP request data flows, possibly through intermediate helper functions, into a SQL query, shell command, file path, or HTTP response without passing through a recognized sanitizer.",
+	HelpURI:     "https://github.com/josephvolmer/yavs/wiki/YAVS-TAINT-001",
+	Severity:    analyzer.Critical,
+}
+
+// Analyzer adapts this package's whole-program analysis to the
+// analyzer.Analyzer interface so its findings can be reported through the
+// same pipeline as the AST-based checkers. Check always returns nil: taint
+// analysis needs the whole program's SSA form, not a single file, so
+// callers must run Analyze separately and merge the results.
+type Analyzer struct{}
+
+// Metadata implements analyzer.Analyzer.
+func (Analyzer) Metadata() analyzer.Metadata { return RuleMetadata }
+
+// Check implements analyzer.Analyzer. It is a no-op; see the package doc.
+func (Analyzer) Check(file *ast.File, fset *token.FileSet) []analyzer.Finding { return nil }
+
+// PathStep is one hop of a source-to-sink taint path.
+type PathStep struct {
+	Description string
+	Pos         token.Position
+}
+
+// Finding is a tainted data flow from a Source to a Sink.
+type Finding struct {
+	Message string
+	Path    []PathStep
+}
+
+// ToAnalyzerFinding renders f using the position of its final (sink) step,
+// for formatters that only understand analyzer.Finding.
+func (f Finding) ToAnalyzerFinding() analyzer.Finding {
+	sink := f.Path[len(f.Path)-1]
+	return analyzer.Finding{
+		RuleID:   RuleID,
+		Severity: RuleMetadata.Severity,
+		Message:  f.Message,
+		Pos:      sink.Pos,
+		EndPos:   sink.Pos,
+	}
+}
+
+// Analyze loads the packages matching patterns under dir, builds their SSA
+// form and call graph, and returns every tainted source-to-sink path found.
+func Analyze(dir string, patterns ...string) ([]Finding, error) {
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedImports |
+			packages.NeedDeps | packages.NeedTypes | packages.NeedTypesInfo |
+			packages.NeedSyntax,
+		Dir: dir,
+	}
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return nil, err
+	}
+
+	prog, _ := ssautil.AllPackages(pkgs, ssa.InstantiateGenerics)
+	prog.Build()
+
+	// CHA (class hierarchy analysis) is a sound over-approximation of the
+	// call graph; it costs more false call-graph edges than RTA but doesn't
+	// require a set of program roots, which suits scanning a library with
+	// no main package.
+	cg := cha.CallGraph(prog)
+
+	return newEngine(prog, cg).run(), nil
+}
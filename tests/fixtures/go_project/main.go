@@ -79,6 +79,33 @@ func search(w http.ResponseWriter, r *http.Request) {
 	fmt.Fprintf(w, "<h1>Search Results for: %s</h1>", query)
 }
 
+// lookupByName logs the requested username but always runs the same fixed
+// query, regardless of what was passed in: its result isn't actually
+// derived from its argument, unlike getUser's query above.
+func lookupByName(name string) string {
+	fmt.Println("looking up:", name)
+	return "SELECT * FROM users LIMIT 1"
+}
+
+// Not a SQL injection: query doesn't depend on the request-controlled input
+func lookupUser(w http.ResponseWriter, r *http.Request) {
+	username := r.URL.Query().Get("username")
+
+	db, err := sql.Open("mysql", "")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer db.Close()
+
+	rows, err := db.Query(lookupByName(username))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer rows.Close()
+}
+
 // Insecure random for security purposes
 func generateToken() string {
 	// Unsafe: math/rand is not cryptographically secure